@@ -0,0 +1,193 @@
+//go:build freebsd
+// +build freebsd
+
+package arp
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// TempLifetime is how long entries added with AddTemp stay in the table,
+// matching "arp -s ... temp".
+const TempLifetime = 20 * time.Minute
+
+// List returns all entries of the IPv4 neighbor table.
+//
+// This is the "arp -an" output. Works without special privileges.
+//
+// Example:
+//
+//	entries, err := arp.List()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, e := range entries {
+//		fmt.Printf("%s at %s on %s\n", e.IP, e.MAC, e.Interface)
+//	}
+func List() ([]Entry, error) {
+	lle, err := routing.ListLL(constants.AF_INET)
+	if err != nil {
+		return nil, fmt.Errorf("list arp entries: %w", err)
+	}
+
+	uptime := isyscall.Uptime()
+	now := time.Now()
+	names := make(map[int]string)
+
+	entries := make([]Entry, 0, len(lle))
+	for _, l := range lle {
+		name, ok := names[l.Index]
+		if !ok {
+			name, _ = ifops.NameByIndex(l.Index)
+			names[l.Index] = name
+		}
+
+		e := Entry{
+			IP:        l.IP,
+			MAC:       l.LLAddr,
+			Interface: name,
+			Permanent: l.Expire == 0,
+			Published: l.Flags&constants.RTF_ANNOUNCE != 0,
+		}
+		if l.Expire != 0 {
+			// ARP expiry times count from boot, not from the epoch
+			e.Expires = now.Add(time.Duration(int64(l.Expire)-uptime) * time.Second)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// Add adds a permanent (static) ARP entry.
+//
+// This is "arp -s <ip> <mac>". The address must be on a subnet directly
+// connected to iface. Adding an existing entry replaces it.
+// Requires root privileges.
+//
+// Example:
+//
+//	mac, _ := net.ParseMAC("58:9c:fc:00:00:01")
+//	if err := arp.Add("bridge0", net.ParseIP("10.0.0.10"), mac); err != nil {
+//		log.Fatal(err)
+//	}
+func Add(iface string, ip net.IP, mac net.HardwareAddr) error {
+	return add(iface, ip, mac, 0, 0)
+}
+
+// AddTemp adds an ARP entry that expires after TempLifetime.
+//
+// This is "arp -s <ip> <mac> temp". Requires root privileges.
+func AddTemp(iface string, ip net.IP, mac net.HardwareAddr) error {
+	return add(iface, ip, mac, 0, TempLifetime)
+}
+
+// AddPublished adds a permanent published ARP entry (proxy-ARP).
+//
+// The host answers ARP requests for ip with mac, usually the MAC address
+// of iface itself. This is "arp -s <ip> <mac> pub". Requires root privileges.
+func AddPublished(iface string, ip net.IP, mac net.HardwareAddr) error {
+	return add(iface, ip, mac, constants.RTF_ANNOUNCE, 0)
+}
+
+func add(iface string, ip net.IP, mac net.HardwareAddr, flags int, lifetime time.Duration) error {
+	if ip.To4() == nil {
+		return isyscall.NewValidationError("ip", ip.String(), "not an IPv4 address")
+	}
+	if len(mac) != 6 {
+		return isyscall.NewValidationError("mac", mac.String(), "not an Ethernet address")
+	}
+
+	ifindex, err := ifops.IndexByName(iface)
+	if err != nil {
+		return fmt.Errorf("add arp entry %s on %s: %w", ip, iface, err)
+	}
+
+	var expire uint64
+	if lifetime > 0 {
+		expire = uint64(isyscall.Uptime() + int64(lifetime/time.Second))
+	}
+
+	if err := routing.AddLL(routing.IPSockaddr(ip), mac, ifindex, flags, expire); err != nil {
+		return fmt.Errorf("add arp entry %s at %s on %s: %w", ip, mac, iface, err)
+	}
+	return nil
+}
+
+// Delete removes an ARP entry.
+//
+// If iface is empty, the interface is looked up in the table.
+// This operation is idempotent - returns nil if the entry doesn't exist.
+// Requires root privileges.
+//
+// Example:
+//
+//	if err := arp.Delete("bridge0", net.ParseIP("10.0.0.10")); err != nil {
+//		log.Fatal(err)
+//	}
+func Delete(iface string, ip net.IP) error {
+	if ip.To4() == nil {
+		return isyscall.NewValidationError("ip", ip.String(), "not an IPv4 address")
+	}
+
+	if iface == "" {
+		entries, err := List()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.IP.Equal(ip) {
+				iface = e.Interface
+				break
+			}
+		}
+		if iface == "" {
+			return nil // Idempotent
+		}
+	}
+
+	ifindex, err := ifops.IndexByName(iface)
+	if err != nil {
+		return fmt.Errorf("delete arp entry %s on %s: %w", ip, iface, err)
+	}
+
+	if err := routing.DelLL(routing.IPSockaddr(ip), ifindex); err != nil {
+		return fmt.Errorf("delete arp entry %s on %s: %w", ip, iface, err)
+	}
+	return nil
+}
+
+// Flush removes all ARP entries of an interface, or of all interfaces if
+// iface is empty.
+//
+// Entries for the host's own addresses are kept. This is "arp -d -a".
+// Requires root privileges.
+func Flush(iface string) error {
+	lle, err := routing.ListLL(constants.AF_INET)
+	if err != nil {
+		return fmt.Errorf("flush arp entries: %w", err)
+	}
+
+	ifindex := 0
+	if iface != "" {
+		if ifindex, err = ifops.IndexByName(iface); err != nil {
+			return fmt.Errorf("flush arp entries on %s: %w", iface, err)
+		}
+	}
+
+	for _, l := range lle {
+		if l.Flags&constants.RTF_PINNED != 0 || (ifindex != 0 && l.Index != ifindex) {
+			continue
+		}
+		if err := routing.DelLL(routing.IPSockaddr(l.IP), l.Index); err != nil {
+			return fmt.Errorf("flush arp entry %s: %w", l.IP, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build freebsd
+// +build freebsd
+
+package arp
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/zombocoder/go-freebsd-ifc/epair"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+)
+
+func skipIfNotRoot(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Test requires root privileges")
+	}
+}
+
+func skipIfNotE2E(t *testing.T) {
+	if os.Getenv("IFCLIB_E2E") != "1" {
+		t.Skip("E2E tests disabled. Set IFCLIB_E2E=1 to enable")
+	}
+}
+
+// TestList tests listing the ARP table
+func TestList(t *testing.T) {
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+
+	for _, e := range entries {
+		if e.IP.To4() == nil {
+			t.Errorf("Entry %v is not IPv4", e.IP)
+		}
+		if e.Permanent != e.Expires.IsZero() {
+			t.Errorf("Entry %v: Permanent=%v but Expires=%v", e.IP, e.Permanent, e.Expires)
+		}
+		t.Logf("%s at %s on %s permanent=%v published=%v",
+			e.IP, e.MAC, e.Interface, e.Permanent, e.Published)
+	}
+}
+
+// TestAddInvalid tests argument validation
+func TestAddInvalid(t *testing.T) {
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+
+	if err := Add("lo0", net.ParseIP("2001:db8::1"), mac); err == nil {
+		t.Error("Add() should fail for IPv6 address")
+	}
+	if err := Add("lo0", net.ParseIP("10.0.0.1"), net.HardwareAddr{1, 2, 3}); err == nil {
+		t.Error("Add() should fail for short MAC address")
+	}
+	if err := Add("nonexistent999", net.ParseIP("10.0.0.1"), mac); err == nil {
+		t.Error("Add() should fail for non-existent interface")
+	}
+}
+
+// TestAddDelete tests static, temporary and published entries on an epair
+func TestAddDelete(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+
+	if err := ip.Add4(pair.A, net.ParseIP("192.0.2.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	mac, _ := net.ParseMAC("02:00:00:00:00:10")
+	static := net.ParseIP("192.0.2.10")
+	temp := net.ParseIP("192.0.2.11")
+	pub := net.ParseIP("192.0.2.12")
+
+	if err := Add(pair.A, static, mac); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := AddTemp(pair.A, temp, mac); err != nil {
+		t.Fatalf("AddTemp() failed: %v", err)
+	}
+	if err := AddPublished(pair.A, pub, mac); err != nil {
+		t.Fatalf("AddPublished() failed: %v", err)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	found := make(map[string]Entry)
+	for _, e := range entries {
+		if e.Interface == pair.A {
+			found[e.IP.String()] = e
+		}
+	}
+
+	if e, ok := found[static.String()]; !ok || !e.Permanent || e.MAC.String() != mac.String() {
+		t.Errorf("Static entry not listed correctly: %+v", e)
+	}
+	if e, ok := found[temp.String()]; !ok || e.Permanent || e.Expires.IsZero() {
+		t.Errorf("Temporary entry not listed correctly: %+v", e)
+	}
+	if e, ok := found[pub.String()]; !ok || !e.Published {
+		t.Errorf("Published entry not listed correctly: %+v", e)
+	}
+
+	if err := Delete("", static); err != nil {
+		t.Errorf("Delete() failed: %v", err)
+	}
+	if err := Delete(pair.A, static); err != nil {
+		t.Errorf("Delete() should be idempotent: %v", err)
+	}
+
+	if err := Flush(pair.A); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+	entries, _ = List()
+	for _, e := range entries {
+		if e.Interface == pair.A && !e.IP.Equal(net.ParseIP("192.0.2.1")) {
+			t.Errorf("Entry %v still present after Flush()", e.IP)
+		}
+	}
+}
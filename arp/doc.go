@@ -0,0 +1,55 @@
+/*
+Package arp provides FreeBSD IPv4 neighbor (ARP) table management.
+
+This is the "arp -an", "arp -s" and "arp -d" functionality. Entries are read
+through the NET_RT_FLAGS routing sysctl and changed with routing socket
+messages carrying a link-layer gateway.
+
+# Basic Usage
+
+List the neighbor table:
+
+	entries, err := arp.List()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range entries {
+		switch {
+		case e.Incomplete():
+			fmt.Printf("%s (incomplete) on %s\n", e.IP, e.Interface)
+		case e.Permanent:
+			fmt.Printf("%s at %s on %s permanent\n", e.IP, e.MAC, e.Interface)
+		default:
+			fmt.Printf("%s at %s on %s expires in %v\n",
+				e.IP, e.MAC, e.Interface, time.Until(e.Expires).Round(time.Second))
+		}
+	}
+
+Pin the MAC address of a VM on a bridge:
+
+	mac, _ := net.ParseMAC("58:9c:fc:00:00:01")
+
+	// arp -s 10.0.0.10 58:9c:fc:00:00:01
+	if err := arp.Add("bridge0", net.ParseIP("10.0.0.10"), mac); err != nil {
+		log.Fatal(err)
+	}
+
+	// arp -d 10.0.0.10
+	if err := arp.Delete("bridge0", net.ParseIP("10.0.0.10")); err != nil {
+		log.Fatal(err)
+	}
+
+# Entry Kinds
+
+  - Add: Permanent (static) entry
+  - AddTemp: Entry that expires after TempLifetime (20 minutes)
+  - AddPublished: Permanent proxy-ARP entry, answered on behalf of another host
+
+The address must belong to a subnet configured on the interface.
+
+# Permissions
+
+List works without special privileges.
+Add, AddTemp, AddPublished, Delete and Flush require root privileges.
+*/
+package arp
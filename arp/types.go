@@ -0,0 +1,22 @@
+//go:build freebsd
+// +build freebsd
+
+package arp
+
+import (
+	"net"
+	"time"
+)
+
+// Entry represents an IPv4 neighbor (ARP) table entry.
+type Entry struct {
+	IP        net.IP           // IPv4 address
+	MAC       net.HardwareAddr // Link-layer address, nil while the entry is incomplete
+	Interface string           // Interface the entry belongs to
+	Expires   time.Time        // Expiry time, zero for permanent entries
+	Permanent bool             // Entry never expires (static)
+	Published bool             // Answered on behalf of the host (proxy-ARP)
+}
+
+// Incomplete reports whether address resolution is still in progress.
+func (e Entry) Incomplete() bool { return len(e.MAC) == 0 }
@@ -0,0 +1,183 @@
+//go:build freebsd
+// +build freebsd
+
+package carp
+
+import (
+	"fmt"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/carpops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Configure creates or updates a virtual host on an interface.
+//
+// This is "ifconfig <iface> vhid <n> advbase <n> advskew <n> pass <secret>".
+// Add the virtual addresses afterwards with ip.Add4WithOptions or
+// ip.Add6WithOptions and the same Vhid. An empty password keeps the
+// current one. Requires root privileges.
+//
+// Example:
+//
+//	err := carp.Configure("em0", carp.Config{VHID: 1, AdvSkew: 100, Password: "secret"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = ip.Add4WithOptions("em0", net.ParseIP("192.0.2.1"), net.CIDRMask(24, 32),
+//		ip.Add4Options{Vhid: 1})
+func Configure(iface string, cfg Config) error {
+	if err := validateVHID(cfg.VHID); err != nil {
+		return err
+	}
+	if cfg.AdvBase < 0 || cfg.AdvBase > 255 {
+		return isyscall.NewValidationError("advbase", fmt.Sprintf("%d", cfg.AdvBase), "must be between 1 and 255, or 0 for the default")
+	}
+	if cfg.AdvSkew < 0 || cfg.AdvSkew > 254 {
+		return isyscall.NewValidationError("advskew", fmt.Sprintf("%d", cfg.AdvSkew), "must be between 0 and 254")
+	}
+	if len(cfg.Password) > constants.CARP_KEY_LEN {
+		return isyscall.NewValidationError("password", "<hidden>", fmt.Sprintf("must be at most %d bytes", constants.CARP_KEY_LEN))
+	}
+
+	err := carpops.Set(iface, carpops.VHID{
+		VHID:    cfg.VHID,
+		AdvBase: cfg.AdvBase,
+		AdvSkew: cfg.AdvSkew,
+		Key:     []byte(cfg.Password),
+	})
+	if err != nil {
+		return fmt.Errorf("configure carp vhid %d on %s: %w", cfg.VHID, iface, err)
+	}
+	return nil
+}
+
+// List returns all virtual hosts configured on an interface, none if it
+// has no CARP. Returns ErrNotFound if the interface does not exist.
+//
+// Works without special privileges, but passwords are only returned to root.
+//
+// Example:
+//
+//	vhids, err := carp.List("em0")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, v := range vhids {
+//		fmt.Printf("carp: %s vhid %d advbase %d advskew %d\n", v.State, v.VHID, v.AdvBase, v.AdvSkew)
+//	}
+func List(iface string) ([]VHID, error) {
+	vs, err := carpops.Get(iface)
+	if err != nil {
+		return nil, fmt.Errorf("list carp on %s: %w", iface, err)
+	}
+
+	vhids := make([]VHID, 0, len(vs))
+	for _, v := range vs {
+		vhids = append(vhids, fromOps(v))
+	}
+	return vhids, nil
+}
+
+// Get returns a single virtual host.
+//
+// Returns ErrNotFound if the vhid is not configured on the interface.
+func Get(iface string, vhid int) (VHID, error) {
+	if err := validateVHID(vhid); err != nil {
+		return VHID{}, err
+	}
+
+	v, err := carpops.GetVHID(iface, vhid)
+	if err != nil {
+		return VHID{}, fmt.Errorf("get carp vhid %d on %s: %w", vhid, iface, err)
+	}
+	return fromOps(v), nil
+}
+
+// SetState forces a running virtual host into a state.
+//
+// StateBackup demotes a master, StateMaster takes over immediately.
+// This is "ifconfig <iface> vhid <n> state backup". A virtual host in
+// StateInit, for example on an interface that is down, ignores the new
+// state, so SetState returns a validation error for it. Requires root
+// privileges.
+//
+// Example:
+//
+//	// Hand over to the peer before maintenance
+//	if err := carp.SetState("em0", 1, carp.StateBackup); err != nil {
+//		log.Fatal(err)
+//	}
+func SetState(iface string, vhid int, state State) error {
+	if state != StateBackup && state != StateMaster {
+		return isyscall.NewValidationError("state", state.String(), "must be BACKUP or MASTER")
+	}
+
+	v, err := Get(iface, vhid)
+	if err != nil {
+		return err
+	}
+	if v.State == StateInit {
+		return isyscall.NewValidationError("state", v.State.String(), "virtual host is not running")
+	}
+
+	// Keep the current parameters; a nil key keeps the current password
+	err = carpops.Set(iface, carpops.VHID{
+		VHID:    vhid,
+		State:   int(state),
+		AdvBase: v.AdvBase,
+		AdvSkew: v.AdvSkew,
+	})
+	if err != nil {
+		return fmt.Errorf("set carp vhid %d on %s to %s: %w", vhid, iface, state, err)
+	}
+	return nil
+}
+
+// Demotion returns the system-wide demotion counter.
+//
+// A non-zero counter makes all virtual hosts on this system advertise with
+// the worst skew, so peers take over. Works without special privileges.
+func Demotion() (int, error) {
+	v, err := isyscall.SysctlInt(constants.SysctlCarpDemotion)
+	if err != nil {
+		return 0, fmt.Errorf("get carp demotion: %w", err)
+	}
+	return v, nil
+}
+
+// AdjustDemotion adds delta to the system-wide demotion counter.
+//
+// A positive delta demotes this system, a negative one undoes it. The
+// kernel clamps the counter at zero. Requires root privileges.
+//
+// Example:
+//
+//	// Force failover to the peer
+//	if err := carp.AdjustDemotion(240); err != nil {
+//		log.Fatal(err)
+//	}
+//	defer carp.AdjustDemotion(-240)
+func AdjustDemotion(delta int) error {
+	if err := isyscall.SetSysctlInt(constants.SysctlCarpDemotion, delta); err != nil {
+		return fmt.Errorf("adjust carp demotion by %d: %w", delta, err)
+	}
+	return nil
+}
+
+func validateVHID(vhid int) error {
+	if vhid < 1 || vhid > constants.CARP_MAXVHID {
+		return isyscall.NewValidationError("vhid", fmt.Sprintf("%d", vhid), "must be between 1 and 255")
+	}
+	return nil
+}
+
+func fromOps(v carpops.VHID) VHID {
+	return VHID{
+		VHID:     v.VHID,
+		State:    State(v.State),
+		AdvBase:  v.AdvBase,
+		AdvSkew:  v.AdvSkew,
+		Password: string(v.Key),
+	}
+}
@@ -0,0 +1,155 @@
+//go:build freebsd
+// +build freebsd
+
+package carp
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/zombocoder/go-freebsd-ifc/epair"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+)
+
+func skipIfNotRoot(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Test requires root privileges")
+	}
+}
+
+func skipIfNotE2E(t *testing.T) {
+	if os.Getenv("IFCLIB_E2E") != "1" {
+		t.Skip("E2E tests disabled. Set IFCLIB_E2E=1 to enable")
+	}
+}
+
+// TestStateString tests state names
+func TestStateString(t *testing.T) {
+	tests := []struct {
+		state State
+		want  string
+	}{
+		{StateInit, "INIT"},
+		{StateBackup, "BACKUP"},
+		{StateMaster, "MASTER"},
+		{State(9), "STATE9"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("State(%d).String() = %q, want %q", int(tt.state), got, tt.want)
+		}
+	}
+}
+
+// TestConfigureInvalid tests argument validation
+func TestConfigureInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"zero vhid", Config{VHID: 0}},
+		{"vhid too large", Config{VHID: 256}},
+		{"advbase too large", Config{VHID: 1, AdvBase: 256}},
+		{"advskew too large", Config{VHID: 1, AdvSkew: 255}},
+		{"password too long", Config{VHID: 1, Password: "0123456789012345678901"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Configure("lo0", tt.cfg); err == nil {
+				t.Error("Configure() should fail")
+			}
+		})
+	}
+}
+
+// TestSetStateInvalid tests that only BACKUP and MASTER can be forced
+func TestSetStateInvalid(t *testing.T) {
+	if err := SetState("lo0", 1, StateInit); err == nil {
+		t.Error("SetState(StateInit) should fail")
+	}
+}
+
+// TestDemotion tests reading the demotion counter
+func TestDemotion(t *testing.T) {
+	d, err := Demotion()
+	if err != nil {
+		t.Skipf("carp(4) not loaded: %v", err)
+	}
+	t.Logf("demotion=%d", d)
+}
+
+// TestGetMissing tests that a vhid lookup reaches the kernel's vhid search
+func TestGetMissing(t *testing.T) {
+	if _, err := Demotion(); err != nil {
+		t.Skipf("carp(4) not loaded: %v", err)
+	}
+
+	// lo0 never has CARP; a malformed request would fail with EMSGSIZE
+	if _, err := Get("lo0", 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() on lo0 should return ErrNotFound, got %v", err)
+	}
+}
+
+// TestListMissingInterface tests that a typo is not reported as no CARP
+func TestListMissingInterface(t *testing.T) {
+	if _, err := List("nonexistent999"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("List() of a missing interface should return ErrNotFound, got %v", err)
+	}
+}
+
+// TestConfigureAddress tests a virtual host with an address on an epair
+func TestConfigureAddress(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	if _, err := Demotion(); err != nil {
+		t.Skipf("carp(4) not loaded: %v", err)
+	}
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+
+	if err := Configure(pair.A, Config{VHID: 42, AdvSkew: 100, Password: "secret"}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+	err = ip.Add4WithOptions(pair.A, net.ParseIP("192.0.2.42"), net.CIDRMask(24, 32), ip.Add4Options{Vhid: 42})
+	if err != nil {
+		t.Fatalf("ip.Add4WithOptions() failed: %v", err)
+	}
+
+	v, err := Get(pair.A, 42)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if v.VHID != 42 || v.AdvSkew != 100 || v.Password != "secret" {
+		t.Errorf("Unexpected vhid state: %+v", v)
+	}
+	t.Logf("vhid %d: %s advbase=%d advskew=%d", v.VHID, v.State, v.AdvBase, v.AdvSkew)
+
+	// The peer end is down, so the vhid may not have left INIT yet
+	if v.State == StateInit {
+		if err := SetState(pair.A, 42, StateMaster); !isyscall.IsValidation(err) {
+			t.Errorf("SetState() in INIT should return a validation error, got %v", err)
+		}
+	}
+
+	vhids, err := List(pair.A)
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(vhids) != 1 {
+		t.Errorf("Expected 1 vhid, got %d", len(vhids))
+	}
+
+	if _, err := Get(pair.A, 43); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of unknown vhid should return ErrNotFound, got %v", err)
+	}
+}
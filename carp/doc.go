@@ -0,0 +1,51 @@
+/*
+Package carp provides FreeBSD CARP (Common Address Redundancy Protocol)
+management.
+
+CARP lets several hosts on a link share virtual addresses: one host is
+MASTER and answers for them, the others are BACKUP and take over when the
+master stops advertising. This is the "ifconfig vhid" functionality and
+requires the carp(4) module (kldload carp).
+
+# Basic Usage
+
+Configure a virtual host and its address on both firewalls:
+
+	// ifconfig em0 vhid 1 advskew 100 pass secret
+	err := carp.Configure("em0", carp.Config{VHID: 1, AdvSkew: 100, Password: "secret"})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ifconfig em0 inet 192.0.2.1/24 vhid 1
+	err = ip.Add4WithOptions("em0", net.ParseIP("192.0.2.1"), net.CIDRMask(24, 32),
+		ip.Add4Options{Vhid: 1})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+Query the state:
+
+	v, err := carp.Get("em0", 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("vhid %d is %s\n", v.VHID, v.State)
+
+# Failover
+
+SetState forces a single virtual host into BACKUP or MASTER. AdjustDemotion
+changes the system-wide demotion counter, which makes every virtual host
+on this system lose elections (with preemption enabled on the peers):
+
+	if err := carp.AdjustDemotion(240); err != nil {
+		log.Fatal(err)
+	}
+
+# Permissions
+
+List, Get and Demotion work without special privileges (passwords are only
+shown to root). Configure, SetState and AdjustDemotion require root
+privileges.
+*/
+package carp
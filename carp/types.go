@@ -0,0 +1,54 @@
+//go:build freebsd
+// +build freebsd
+
+package carp
+
+import (
+	"strconv"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// State represents the CARP state of a virtual host.
+type State int
+
+const (
+	StateInit   State = 0 // Not running (interface down or no addresses)
+	StateBackup State = 1 // Listening for the master's advertisements
+	StateMaster State = 2 // Owning the virtual addresses
+)
+
+// String returns the state as printed by ifconfig(8), e.g. "MASTER".
+func (s State) String() string {
+	switch s {
+	case StateInit:
+		return "INIT"
+	case StateBackup:
+		return "BACKUP"
+	case StateMaster:
+		return "MASTER"
+	}
+	return "STATE" + strconv.Itoa(int(s))
+}
+
+// Config configures a virtual host.
+type Config struct {
+	VHID     int    // Virtual host ID (1-255)
+	AdvBase  int    // Advertisement interval base in seconds (1-255), 0 for the default of 1
+	AdvSkew  int    // Advertisement interval skew (0-254), higher values make a worse master
+	Password string // Shared secret for the advertisement HMAC (at most 20 bytes)
+}
+
+// VHID represents the state of a virtual host.
+type VHID struct {
+	VHID     int    // Virtual host ID
+	State    State  // Current CARP state
+	AdvBase  int    // Advertisement interval base in seconds
+	AdvSkew  int    // Advertisement interval skew
+	Password string // Shared secret, only visible to root
+}
+
+// Re-export common errors from internal package
+var (
+	ErrNotFound = syscall.ErrNotFound
+)
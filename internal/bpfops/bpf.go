@@ -0,0 +1,78 @@
+//go:build freebsd
+// +build freebsd
+
+package bpfops
+
+/*
+#include <sys/types.h>
+#include <sys/socket.h>
+#include <net/if.h>
+*/
+import "C"
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Writer sends complete link-layer frames on an interface through a BPF
+// device
+type Writer struct {
+	fd int
+}
+
+// Open opens a BPF device bound to an interface for writing.
+//
+// The header-complete flag is set, so the source address in written frames
+// is sent as is instead of being replaced by the interface's address.
+func Open(name string) (*Writer, error) {
+	if len(name) >= constants.IFNAMSIZ {
+		return nil, isyscall.NewValidationError("name", name, "interface name too long")
+	}
+
+	fd, err := syscall.Open(constants.BPFDevice, syscall.O_WRONLY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	var ifr C.struct_ifreq
+	isyscall.CopyString(unsafe.Pointer(&ifr.ifr_name[0]), name, constants.IFNAMSIZ)
+	if err := isyscall.Ioctl(fd, constants.BIOCSETIF, unsafe.Pointer(&ifr)); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	complete := C.u_int(1)
+	if err := isyscall.Ioctl(fd, constants.BIOCSHDRCMPLT, unsafe.Pointer(&complete)); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	return &Writer{fd: fd}, nil
+}
+
+// Write sends one frame
+func (w *Writer) Write(frame []byte) error {
+	n, err := syscall.Write(w.fd, frame)
+	if err != nil {
+		return mapError(err)
+	}
+	if n != len(frame) {
+		return isyscall.MapError(syscall.EIO)
+	}
+	return nil
+}
+
+// Close closes the BPF device
+func (w *Writer) Close() error {
+	return syscall.Close(w.fd)
+}
+
+func mapError(err error) error {
+	if errno, ok := err.(syscall.Errno); ok {
+		return isyscall.MapError(errno)
+	}
+	return err
+}
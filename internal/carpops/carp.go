@@ -0,0 +1,133 @@
+//go:build freebsd
+// +build freebsd
+
+package carpops
+
+/*
+#include <sys/types.h>
+#include <sys/socket.h>
+#include <net/if.h>
+#include <netinet/in.h>
+#include <netinet/ip_carp.h>
+#include <string.h>
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// VHID represents the state of a virtual host (struct carpreq)
+type VHID struct {
+	VHID    int
+	State   int // INIT, BACKUP or MASTER (enum carp_states)
+	AdvSkew int
+	AdvBase int
+	Key     []byte // Only returned to privileged callers
+}
+
+// Get returns all virtual hosts configured on an interface
+func Get(name string) ([]VHID, error) {
+	if len(name) >= constants.IFNAMSIZ {
+		return nil, isyscall.NewValidationError("name", name, "interface name too long")
+	}
+
+	// The kernel fills one carpreq per vhid when carpr_vhid is 0
+	var reqs [constants.CARP_MAXVHID]C.struct_carpreq
+	reqs[0].carpr_count = constants.CARP_MAXVHID
+
+	if err := ioctl(name, constants.SIOCGVH, unsafe.Pointer(&reqs[0])); err != nil {
+		if errors.Is(err, isyscall.ErrNotFound) {
+			// ENXIO for a missing interface maps to ErrNotFound as well
+			if _, err := ifops.IndexByName(name); err != nil {
+				return nil, err
+			}
+			return nil, nil // No CARP on this interface
+		}
+		return nil, err
+	}
+
+	var vhids []VHID
+	for i := range reqs {
+		if reqs[i].carpr_vhid == 0 {
+			break
+		}
+		vhids = append(vhids, fromReq(&reqs[i]))
+	}
+	return vhids, nil
+}
+
+// GetVHID returns a single virtual host
+func GetVHID(name string, vhid int) (VHID, error) {
+	if len(name) >= constants.IFNAMSIZ {
+		return VHID{}, isyscall.NewValidationError("name", name, "interface name too long")
+	}
+
+	// The kernel rejects a request without room for one carpreq
+	var req C.struct_carpreq
+	req.carpr_vhid = C.int(vhid)
+	req.carpr_count = 1
+	if err := ioctl(name, constants.SIOCGVH, unsafe.Pointer(&req)); err != nil {
+		return VHID{}, err
+	}
+	return fromReq(&req), nil
+}
+
+// Set creates or updates a virtual host.
+//
+// advbase 0 keeps the current (or default) interval, a nil key keeps the
+// current key and state is only applied to running virtual hosts.
+func Set(name string, v VHID) error {
+	if len(name) >= constants.IFNAMSIZ {
+		return isyscall.NewValidationError("name", name, "interface name too long")
+	}
+	if len(v.Key) > constants.CARP_KEY_LEN {
+		return isyscall.NewValidationError("key", string(v.Key), "password too long")
+	}
+
+	var req C.struct_carpreq
+	req.carpr_vhid = C.int(v.VHID)
+	req.carpr_state = C.int(v.State)
+	req.carpr_advskew = C.int(v.AdvSkew)
+	req.carpr_advbase = C.int(v.AdvBase)
+	if len(v.Key) > 0 {
+		isyscall.CopyBytes(unsafe.Pointer(&req.carpr_key[0]), unsafe.Pointer(&v.Key[0]), len(v.Key))
+	}
+
+	return ioctl(name, constants.SIOCSVH, unsafe.Pointer(&req))
+}
+
+func ioctl(name string, request uintptr, data unsafe.Pointer) error {
+	s, err := isyscall.CreateInetSocket()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	var ifr C.struct_ifreq
+	isyscall.CopyString(unsafe.Pointer(&ifr.ifr_name[0]), name, constants.IFNAMSIZ)
+	*(*C.caddr_t)(unsafe.Pointer(&ifr.ifr_ifru)) = C.caddr_t(data)
+
+	return isyscall.Ioctl(s.Int(), request, unsafe.Pointer(&ifr))
+}
+
+func fromReq(req *C.struct_carpreq) VHID {
+	key := C.GoBytes(unsafe.Pointer(&req.carpr_key[0]), constants.CARP_KEY_LEN)
+	if n := C.strnlen((*C.char)(unsafe.Pointer(&req.carpr_key[0])), constants.CARP_KEY_LEN); n > 0 {
+		key = key[:n]
+	} else {
+		key = nil
+	}
+
+	return VHID{
+		VHID:    int(req.carpr_vhid),
+		State:   int(req.carpr_state),
+		AdvSkew: int(req.carpr_advskew),
+		AdvBase: int(req.carpr_advbase),
+		Key:     key,
+	}
+}
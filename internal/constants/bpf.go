@@ -0,0 +1,23 @@
+//go:build freebsd
+// +build freebsd
+
+package constants
+
+/*
+#include <sys/types.h>
+#include <sys/ioctl.h>
+#include <sys/time.h>
+#include <net/bpf.h>
+*/
+import "C"
+
+// BPF ioctls
+const (
+	BIOCSETIF     = C.BIOCSETIF
+	BIOCSHDRCMPLT = C.BIOCSHDRCMPLT
+)
+
+// BPF device
+const (
+	BPFDevice = "/dev/bpf"
+)
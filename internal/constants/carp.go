@@ -0,0 +1,32 @@
+//go:build freebsd
+// +build freebsd
+
+package constants
+
+/*
+#include <sys/types.h>
+#include <sys/socket.h>
+#include <sys/ioctl.h>
+#include <net/if.h>
+#include <netinet/in.h>
+#include <netinet/ip_carp.h>
+*/
+import "C"
+
+// CARP ioctls
+const (
+	SIOCSVH = C.SIOCSVH
+	SIOCGVH = C.SIOCGVH
+)
+
+// CARP limits and defaults
+const (
+	CARP_MAXVHID  = C.CARP_MAXVHID
+	CARP_KEY_LEN  = C.CARP_KEY_LEN
+	CARP_DFLTINTV = C.CARP_DFLTINTV
+)
+
+// CARP sysctl names
+const (
+	SysctlCarpDemotion = "net.inet.carp.demotion"
+)
@@ -55,6 +55,13 @@ const (
 	SIOCDIFADDR     = C.SIOCDIFADDR
 	SIOCAIFADDR_IN6 = C.SIOCAIFADDR_IN6
 	SIOCDIFADDR_IN6 = C.SIOCDIFADDR_IN6
+
+	SIOCGIFAFLAG_IN6 = C.SIOCGIFAFLAG_IN6
+)
+
+// IPv4 sysctl names
+const (
+	SysctlIPForwarding = "net.inet.ip.forwarding"
 )
 
 // Interface flags
@@ -89,3 +96,24 @@ const (
 const (
 	ND6_INFINITE_LIFETIME = 0xffffffff
 )
+
+// IPv6 address flags (in6_aliasreq.ifra_flags)
+const (
+	IN6_IFF_ANYCAST       = C.IN6_IFF_ANYCAST
+	IN6_IFF_TENTATIVE     = C.IN6_IFF_TENTATIVE
+	IN6_IFF_DUPLICATED    = C.IN6_IFF_DUPLICATED
+	IN6_IFF_DETACHED      = C.IN6_IFF_DETACHED
+	IN6_IFF_DEPRECATED    = C.IN6_IFF_DEPRECATED
+	IN6_IFF_NODAD         = C.IN6_IFF_NODAD
+	IN6_IFF_AUTOCONF      = C.IN6_IFF_AUTOCONF
+	IN6_IFF_TEMPORARY     = C.IN6_IFF_TEMPORARY
+	IN6_IFF_PREFER_SOURCE = C.IN6_IFF_PREFER_SOURCE
+)
+
+// IPv6 address selection policy (ip6addrctl)
+const (
+	SIOCAADDRCTL_POLICY   = C.SIOCAADDRCTL_POLICY
+	SIOCDADDRCTL_POLICY   = C.SIOCDADDRCTL_POLICY
+	IPPROTO_IPV6          = C.IPPROTO_IPV6
+	IPV6CTL_ADDRCTLPOLICY = C.IPV6CTL_ADDRCTLPOLICY
+)
@@ -0,0 +1,65 @@
+//go:build freebsd
+// +build freebsd
+
+package constants
+
+/*
+#include <sys/types.h>
+#include <sys/socket.h>
+#include <sys/ioctl.h>
+#include <net/if.h>
+#include <netinet/in.h>
+#include <netinet/icmp6.h>
+#include <netinet6/in6_var.h>
+#include <netinet6/nd6.h>
+*/
+import "C"
+
+// Neighbor Discovery ioctls
+const (
+	SIOCGIFINFO_IN6 = C.SIOCGIFINFO_IN6
+	SIOCSIFINFO_IN6 = C.SIOCSIFINFO_IN6
+
+	SIOCSRTRFLUSH_IN6 = C.SIOCSRTRFLUSH_IN6
+	SIOCSPFXFLUSH_IN6 = C.SIOCSPFXFLUSH_IN6
+)
+
+// Default router and prefix list sysctls (CTL_NET.PF_INET6.IPPROTO_ICMPV6)
+const (
+	IPPROTO_ICMPV6       = C.IPPROTO_ICMPV6
+	ICMPV6CTL_ND6_DRLIST = C.ICMPV6CTL_ND6_DRLIST
+	ICMPV6CTL_ND6_PRLIST = C.ICMPV6CTL_ND6_PRLIST
+)
+
+// Router Advertisement flags (in6_defrouter.flags)
+const (
+	ND_RA_FLAG_MANAGED     = C.ND_RA_FLAG_MANAGED
+	ND_RA_FLAG_OTHER       = C.ND_RA_FLAG_OTHER
+	ND_RA_FLAG_RTPREF_MASK = C.ND_RA_FLAG_RTPREF_MASK
+)
+
+// Prefix state flags (in6_prefix.flags)
+const (
+	NDPRF_ONLINK   = C.NDPRF_ONLINK
+	NDPRF_DETACHED = C.NDPRF_DETACHED
+)
+
+// Per-interface Neighbor Discovery flags (nd_ifinfo.flags)
+const (
+	ND6_IFF_PERFORMNUD       = C.ND6_IFF_PERFORMNUD
+	ND6_IFF_ACCEPT_RTADV     = C.ND6_IFF_ACCEPT_RTADV
+	ND6_IFF_PREFER_SOURCE    = C.ND6_IFF_PREFER_SOURCE
+	ND6_IFF_IFDISABLED       = C.ND6_IFF_IFDISABLED
+	ND6_IFF_DONT_SET_IFROUTE = C.ND6_IFF_DONT_SET_IFROUTE
+	ND6_IFF_AUTO_LINKLOCAL   = C.ND6_IFF_AUTO_LINKLOCAL
+	ND6_IFF_NO_RADR          = C.ND6_IFF_NO_RADR
+	ND6_IFF_NO_PREFER_IFACE  = C.ND6_IFF_NO_PREFER_IFACE
+	ND6_IFF_NO_DAD           = C.ND6_IFF_NO_DAD
+)
+
+// IPv6 sysctl names
+const (
+	SysctlUseTempAddr    = "net.inet6.ip6.use_tempaddr"
+	SysctlPreferTempAddr = "net.inet6.ip6.prefer_tempaddr"
+	SysctlIP6Forwarding  = "net.inet6.ip6.forwarding"
+)
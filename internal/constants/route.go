@@ -6,23 +6,62 @@ package constants
 /*
 #include <sys/types.h>
 #include <sys/socket.h>
+#include <sys/sysctl.h>
+#include <net/if.h>
+#include <net/if_dl.h>
+#include <net/if_types.h>
 #include <net/route.h>
 */
 import "C"
 
 // Routing message types
 const (
-	RTM_ADD     = C.RTM_ADD
-	RTM_DELETE  = C.RTM_DELETE
-	RTM_VERSION = C.RTM_VERSION
+	RTM_ADD        = C.RTM_ADD
+	RTM_DELETE     = C.RTM_DELETE
+	RTM_CHANGE     = C.RTM_CHANGE
+	RTM_GET        = C.RTM_GET
+	RTM_LOSING     = C.RTM_LOSING
+	RTM_REDIRECT   = C.RTM_REDIRECT
+	RTM_MISS       = C.RTM_MISS
+	RTM_LOCK       = C.RTM_LOCK
+	RTM_RESOLVE    = C.RTM_RESOLVE
+	RTM_NEWADDR    = C.RTM_NEWADDR
+	RTM_DELADDR    = C.RTM_DELADDR
+	RTM_IFINFO     = C.RTM_IFINFO
+	RTM_NEWMADDR   = C.RTM_NEWMADDR
+	RTM_DELMADDR   = C.RTM_DELMADDR
+	RTM_IFANNOUNCE = C.RTM_IFANNOUNCE
+	RTM_IEEE80211  = C.RTM_IEEE80211
+	RTM_VERSION    = C.RTM_VERSION
 )
 
 // Routing flags
 const (
-	RTF_UP      = C.RTF_UP
-	RTF_GATEWAY = C.RTF_GATEWAY
-	RTF_HOST    = C.RTF_HOST
-	RTF_STATIC  = C.RTF_STATIC
+	RTF_UP        = C.RTF_UP
+	RTF_GATEWAY   = C.RTF_GATEWAY
+	RTF_HOST      = C.RTF_HOST
+	RTF_STATIC    = C.RTF_STATIC
+	RTF_REJECT    = C.RTF_REJECT
+	RTF_BLACKHOLE = C.RTF_BLACKHOLE
+	RTF_LLDATA    = C.RTF_LLDATA
+	RTF_ANNOUNCE  = C.RTF_ANNOUNCE
+	RTF_PINNED    = C.RTF_PINNED
+)
+
+// Interface announcements (ifan_what)
+const (
+	IFAN_ARRIVAL   = C.IFAN_ARRIVAL
+	IFAN_DEPARTURE = C.IFAN_DEPARTURE
+)
+
+// Routing message structure sizes
+const (
+	SizeofRtMsghdr         = C.sizeof_struct_rt_msghdr
+	SizeofIfMsghdr         = C.sizeof_struct_if_msghdr
+	SizeofIfaMsghdr        = C.sizeof_struct_ifa_msghdr
+	SizeofIfmaMsghdr       = C.sizeof_struct_ifma_msghdr
+	SizeofIfAnnounceMsghdr = C.sizeof_struct_if_announcemsghdr
+	SizeofSockaddrDL       = C.sizeof_struct_sockaddr_dl
 )
 
 // Routing address types
@@ -31,3 +70,58 @@ const (
 	RTA_GATEWAY = C.RTA_GATEWAY
 	RTA_NETMASK = C.RTA_NETMASK
 )
+
+// Routing address indexes (bit positions of the RTA_* flags)
+const (
+	RTAX_DST     = C.RTAX_DST
+	RTAX_GATEWAY = C.RTAX_GATEWAY
+	RTAX_NETMASK = C.RTAX_NETMASK
+	RTAX_GENMASK = C.RTAX_GENMASK
+	RTAX_IFP     = C.RTAX_IFP
+	RTAX_IFA     = C.RTAX_IFA
+	RTAX_AUTHOR  = C.RTAX_AUTHOR
+	RTAX_BRD     = C.RTAX_BRD
+	RTAX_MAX     = C.RTAX_MAX
+)
+
+// Route metric initialization flags (rtm_inits)
+const (
+	RTV_MTU      = C.RTV_MTU
+	RTV_HOPCOUNT = C.RTV_HOPCOUNT
+	RTV_EXPIRE   = C.RTV_EXPIRE
+	RTV_WEIGHT   = C.RTV_WEIGHT
+)
+
+// Route weight limits (multipath)
+const (
+	RT_DEFAULT_WEIGHT = C.RT_DEFAULT_WEIGHT
+	RT_MAX_WEIGHT     = C.RT_MAX_WEIGHT
+)
+
+// Routing sysctl (CTL_NET.PF_ROUTE) operations
+const (
+	CTL_NET      = C.CTL_NET
+	PF_ROUTE     = C.PF_ROUTE
+	NET_RT_DUMP  = C.NET_RT_DUMP
+	NET_RT_FLAGS = C.NET_RT_FLAGS
+)
+
+// Routing sysctls
+const (
+	SysctlRouteMultipath = "net.route.multipath" // Add routes to an existing destination as nexthops
+)
+
+// Socket options
+const (
+	SO_SETFIB = C.SO_SETFIB
+)
+
+// Routing socket options (level PF_ROUTE)
+const (
+	ROUTE_MSGFILTER = C.ROUTE_MSGFILTER // Deliver only the message types set in a bitmask of 1 << RTM_*
+)
+
+// Interface types
+const (
+	IFT_ETHER = C.IFT_ETHER
+)
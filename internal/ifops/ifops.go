@@ -23,11 +23,12 @@ import (
 
 // Interface represents internal interface data
 type Interface struct {
-	Name  string
-	Index int
-	MTU   int
-	Flags uint32
-	Addrs []net.Addr
+	Name   string
+	Index  int
+	MTU    int
+	Flags  uint32
+	Addrs  []net.Addr
+	HWAddr net.HardwareAddr // Link-layer address, nil if the interface has none
 }
 
 // List returns all network interfaces
@@ -60,6 +61,10 @@ func List() ([]Interface, error) {
 			case constants.AF_LINK:
 				sdl := (*C.struct_sockaddr_dl)(unsafe.Pointer(ifa.ifa_addr))
 				iface.Index = int(sdl.sdl_index)
+				if sdl.sdl_alen > 0 {
+					lladdr := unsafe.Add(unsafe.Pointer(&sdl.sdl_data[0]), int(sdl.sdl_nlen))
+					iface.HWAddr = C.GoBytes(lladdr, C.int(sdl.sdl_alen))
+				}
 
 			case constants.AF_INET:
 				sin := (*C.struct_sockaddr_in)(unsafe.Pointer(ifa.ifa_addr))
@@ -79,6 +84,10 @@ func List() ([]Interface, error) {
 				sin6 := (*C.struct_sockaddr_in6)(unsafe.Pointer(ifa.ifa_addr))
 				ip := make(net.IP, 16)
 				isyscall.CopyBytes(unsafe.Pointer(&ip[0]), unsafe.Pointer(&sin6.sin6_addr), 16)
+				if ip.IsLinkLocalUnicast() {
+					// Drop the KAME embedded scope, if the kernel left it in
+					ip[2], ip[3] = 0, 0
+				}
 
 				var mask net.IPMask
 				if ifa.ifa_netmask != nil {
@@ -107,3 +116,17 @@ func List() ([]Interface, error) {
 
 	return result, nil
 }
+
+// HardwareAddr returns the link-layer address of an interface
+func HardwareAddr(name string) (net.HardwareAddr, error) {
+	ifaces, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, iface := range ifaces {
+		if iface.Name == name {
+			return iface.HWAddr, nil
+		}
+	}
+	return nil, isyscall.ErrNotFound
+}
@@ -7,6 +7,7 @@ package ifops
 #include <sys/types.h>
 #include <net/if.h>
 #include <string.h>
+#include <stdlib.h>
 */
 import "C"
 import (
@@ -104,3 +105,24 @@ func Rename(oldName, newName string) error {
 
 	return isyscall.Ioctl(s.Int(), constants.SIOCSIFNAME, unsafe.Pointer(&ifr))
 }
+
+// NameByIndex returns the name of the interface with the given index
+func NameByIndex(index int) (string, error) {
+	var buf [C.IF_NAMESIZE]C.char
+	if C.if_indextoname(C.uint(index), &buf[0]) == nil {
+		return "", isyscall.ErrNotFound
+	}
+	return C.GoString(&buf[0]), nil
+}
+
+// IndexByName returns the index of the named interface
+func IndexByName(name string) (int, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	index := C.if_nametoindex(cname)
+	if index == 0 {
+		return 0, isyscall.ErrNotFound
+	}
+	return int(index), nil
+}
@@ -12,16 +12,28 @@ package ipaddr
 */
 import "C"
 import (
+	"errors"
 	"fmt"
 	"net"
+	"syscall"
 	"unsafe"
 
 	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
 	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
 )
 
+// Add4Options holds the optional ifaliasreq fields for Add4WithOptions
+type Add4Options struct {
+	Vhid int // CARP virtual host ID, 0 for none
+}
+
 // Add4 adds an IPv4 address to an interface
 func Add4(iface string, ip net.IP, mask net.IPMask) error {
+	return Add4WithOptions(iface, ip, mask, Add4Options{})
+}
+
+// Add4WithOptions adds an IPv4 address, optionally bound to a CARP vhid
+func Add4WithOptions(iface string, ip net.IP, mask net.IPMask, opts Add4Options) error {
 	s, err := isyscall.CreateInetSocket()
 	if err != nil {
 		return err
@@ -56,6 +68,8 @@ func Add4(iface string, ip net.IP, mask net.IPMask) error {
 	}
 	isyscall.CopyBytes(unsafe.Pointer(&bcast.sin_addr), unsafe.Pointer(&bcastIP[0]), 4)
 
+	req.ifra_vhid = C.int(opts.Vhid)
+
 	err = isyscall.Ioctl(s.Int(), constants.SIOCAIFADDR, unsafe.Pointer(&req))
 	if err != nil && err == isyscall.ErrExists {
 		return nil // Idempotent
@@ -82,15 +96,31 @@ func Del4(iface string, ip net.IP, mask net.IPMask) error {
 	addr.sin_len = constants.SizeofSockaddrIn
 	isyscall.CopyBytes(unsafe.Pointer(&addr.sin_addr), unsafe.Pointer(&ip[0]), 4)
 
-	err = isyscall.Ioctl(s.Int(), constants.SIOCDIFADDR, unsafe.Pointer(&req))
+	err = addrError(isyscall.Ioctl(s.Int(), constants.SIOCDIFADDR, unsafe.Pointer(&req)))
 	if err != nil && err == isyscall.ErrNotFound {
 		return nil // Idempotent
 	}
 	return err
 }
 
+// Add6Options holds the optional in6_aliasreq fields for Add6WithOptions
+type Add6Options struct {
+	ValidLifetime     uint32 // Seconds, ND6_INFINITE_LIFETIME for no expiry
+	PreferredLifetime uint32 // Seconds, ND6_INFINITE_LIFETIME for no expiry
+	Flags             int    // IN6_IFF_* flags
+	Vhid              int    // CARP virtual host ID, 0 for none
+}
+
 // Add6 adds an IPv6 address to an interface
 func Add6(iface string, ip net.IP, prefixLen int) error {
+	return Add6WithOptions(iface, ip, prefixLen, Add6Options{
+		ValidLifetime:     constants.ND6_INFINITE_LIFETIME,
+		PreferredLifetime: constants.ND6_INFINITE_LIFETIME,
+	})
+}
+
+// Add6WithOptions adds an IPv6 address with explicit lifetimes and flags
+func Add6WithOptions(iface string, ip net.IP, prefixLen int, opts Add6Options) error {
 	s, err := isyscall.CreateInet6Socket()
 	if err != nil {
 		return err
@@ -116,9 +146,11 @@ func Add6(iface string, ip net.IP, prefixLen int) error {
 	prefixMask := net.CIDRMask(prefixLen, 128)
 	isyscall.CopyBytes(unsafe.Pointer(&mask.sin6_addr), unsafe.Pointer(&prefixMask[0]), 16)
 
-	// Set lifetime
-	req.ifra_lifetime.ia6t_vltime = constants.ND6_INFINITE_LIFETIME
-	req.ifra_lifetime.ia6t_pltime = constants.ND6_INFINITE_LIFETIME
+	// Set lifetime and flags; the kernel derives the expiry times
+	req.ifra_lifetime.ia6t_vltime = C.uint32_t(opts.ValidLifetime)
+	req.ifra_lifetime.ia6t_pltime = C.uint32_t(opts.PreferredLifetime)
+	req.ifra_flags = C.int(opts.Flags)
+	req.ifra_vhid = C.int(opts.Vhid)
 
 	err = isyscall.Ioctl(s.Int(), constants.SIOCAIFADDR_IN6, unsafe.Pointer(&req))
 	if err != nil && err == isyscall.ErrExists {
@@ -146,9 +178,49 @@ func Del6(iface string, ip net.IP, prefixLen int) error {
 	addr.sin6_len = constants.SizeofSockaddrIn6
 	isyscall.CopyBytes(unsafe.Pointer(&addr.sin6_addr), unsafe.Pointer(&ip[0]), 16)
 
-	err = isyscall.Ioctl(s.Int(), constants.SIOCDIFADDR_IN6, unsafe.Pointer(&req))
+	err = addrError(isyscall.Ioctl(s.Int(), constants.SIOCDIFADDR_IN6, unsafe.Pointer(&req)))
 	if err != nil && err == isyscall.ErrNotFound {
 		return nil // Idempotent
 	}
 	return err
 }
+
+// Flags6 returns the IN6_IFF_* flags of an IPv6 address on an interface
+func Flags6(iface string, ip net.IP) (int, error) {
+	if ip.To16() == nil || ip.To4() != nil {
+		return 0, isyscall.NewValidationError("ip", ip.String(), "not an IPv6 address")
+	}
+	ip = ip.To16()
+
+	s, err := isyscall.CreateInet6Socket()
+	if err != nil {
+		return 0, err
+	}
+	defer s.Close()
+
+	var req C.struct_in6_ifreq
+	if len(iface) >= constants.IFNAMSIZ {
+		return 0, fmt.Errorf("interface name too long: %s", iface)
+	}
+	isyscall.CopyString(unsafe.Pointer(&req.ifr_name[0]), iface, constants.IFNAMSIZ)
+
+	addr := (*C.struct_sockaddr_in6)(unsafe.Pointer(&req.ifr_ifru))
+	addr.sin6_family = constants.AF_INET6
+	addr.sin6_len = constants.SizeofSockaddrIn6
+	isyscall.CopyBytes(unsafe.Pointer(&addr.sin6_addr), unsafe.Pointer(&ip[0]), 16)
+
+	if err := isyscall.Ioctl(s.Int(), constants.SIOCGIFAFLAG_IN6, unsafe.Pointer(&req)); err != nil {
+		return 0, addrError(err)
+	}
+
+	return int(*(*C.int)(unsafe.Pointer(&req.ifr_ifru))), nil
+}
+
+// addrError maps EADDRNOTAVAIL, which address ioctls return for addresses
+// not configured on the interface, to ErrNotFound
+func addrError(err error) error {
+	if errors.Is(err, syscall.EADDRNOTAVAIL) {
+		return isyscall.ErrNotFound
+	}
+	return err
+}
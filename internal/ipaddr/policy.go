@@ -0,0 +1,90 @@
+//go:build freebsd
+// +build freebsd
+
+package ipaddr
+
+/*
+#include <sys/types.h>
+#include <net/if.h>
+#include <netinet/in.h>
+#include <netinet6/in6_var.h>
+*/
+import "C"
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Policy represents an address selection policy entry (struct in6_addrpolicy)
+type Policy struct {
+	Addr       [16]byte
+	Mask       [16]byte
+	Precedence int
+	Label      int
+	Use        uint64 // Number of times the entry matched
+}
+
+// ListPolicies returns the address selection policy table
+func ListPolicies() ([]Policy, error) {
+	mib := []int32{constants.CTL_NET, constants.AF_INET6, constants.IPPROTO_IPV6, constants.IPV6CTL_ADDRCTLPOLICY}
+	buf, err := isyscall.Sysctl(mib)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []Policy
+	for len(buf) >= C.sizeof_struct_in6_addrpolicy {
+		var ap C.struct_in6_addrpolicy
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&ap)), C.sizeof_struct_in6_addrpolicy), buf)
+		buf = buf[C.sizeof_struct_in6_addrpolicy:]
+
+		p := Policy{
+			Precedence: int(ap.preced),
+			Label:      int(ap.label),
+			Use:        uint64(ap.use),
+		}
+		copy(p.Addr[:], unsafe.Slice((*byte)(unsafe.Pointer(&ap.addr.sin6_addr)), 16))
+		copy(p.Mask[:], unsafe.Slice((*byte)(unsafe.Pointer(&ap.addrmask.sin6_addr)), 16))
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// AddPolicy adds an address selection policy entry
+func AddPolicy(p Policy) error {
+	return policyIoctl(constants.SIOCAADDRCTL_POLICY, p)
+}
+
+// DelPolicy deletes the policy entry with p's prefix. The kernel reports
+// a missing entry with ESRCH, which maps to ErrNotFound.
+func DelPolicy(p Policy) error {
+	err := policyIoctl(constants.SIOCDADDRCTL_POLICY, p)
+	if errors.Is(err, syscall.ESRCH) {
+		return isyscall.ErrNotFound
+	}
+	return err
+}
+
+func policyIoctl(req uintptr, p Policy) error {
+	s, err := isyscall.CreateInet6Socket()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	var ap C.struct_in6_addrpolicy
+	ap.addr.sin6_family = constants.AF_INET6
+	ap.addr.sin6_len = constants.SizeofSockaddrIn6
+	isyscall.CopyBytes(unsafe.Pointer(&ap.addr.sin6_addr), unsafe.Pointer(&p.Addr[0]), 16)
+	ap.addrmask.sin6_family = constants.AF_INET6
+	ap.addrmask.sin6_len = constants.SizeofSockaddrIn6
+	isyscall.CopyBytes(unsafe.Pointer(&ap.addrmask.sin6_addr), unsafe.Pointer(&p.Mask[0]), 16)
+	ap.preced = C.int(p.Precedence)
+	ap.label = C.int(p.Label)
+
+	return isyscall.Ioctl(s.Int(), req, unsafe.Pointer(&ap))
+}
@@ -0,0 +1,148 @@
+//go:build freebsd
+// +build freebsd
+
+package nd6ops
+
+/*
+#include <sys/types.h>
+#include <sys/socket.h>
+#include <net/if.h>
+#include <netinet/in.h>
+#include <netinet6/in6_var.h>
+#include <netinet6/nd6.h>
+
+// cgo cannot access bit-fields
+static int prefix_onlink(struct in6_prefix *p) { return p->raflags.onlink; }
+static int prefix_autonomous(struct in6_prefix *p) { return p->raflags.autonomous; }
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// DefRouter represents a default router list entry (struct in6_defrouter)
+type DefRouter struct {
+	Addr     [16]byte
+	ZoneID   uint32
+	Flags    uint8
+	Lifetime uint16 // sec
+	Expire   int64  // Wall-clock seconds, 0 = never
+	Index    int
+}
+
+// Prefix represents a prefix list entry (struct in6_prefix)
+type Prefix struct {
+	Addr        [16]byte
+	PrefixLen   int
+	OnLink      bool
+	Autonomous  bool
+	VLTime      uint32 // sec, ND6_INFINITE_LIFETIME = infinite
+	PLTime      uint32 // sec, ND6_INFINITE_LIFETIME = infinite
+	Expire      int64  // Wall-clock seconds, 0 = never
+	Flags       uint32 // NDPRF_*
+	Index       int
+	Advertisers []DefRouter // Only Addr and ZoneID are set
+}
+
+// DefaultRouters returns the default router list
+func DefaultRouters() ([]DefRouter, error) {
+	buf, err := isyscall.Sysctl(icmp6Mib(constants.ICMPV6CTL_ND6_DRLIST))
+	if err != nil {
+		return nil, err
+	}
+
+	var routers []DefRouter
+	for len(buf) >= C.sizeof_struct_in6_defrouter {
+		var d C.struct_in6_defrouter
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&d)), C.sizeof_struct_in6_defrouter), buf)
+		buf = buf[C.sizeof_struct_in6_defrouter:]
+
+		r := DefRouter{
+			ZoneID:   uint32(d.rtaddr.sin6_scope_id),
+			Flags:    uint8(d.flags),
+			Lifetime: uint16(d.rtlifetime),
+			Expire:   int64(d.expire),
+			Index:    int(d.if_index),
+		}
+		copy(r.Addr[:], unsafe.Slice((*byte)(unsafe.Pointer(&d.rtaddr.sin6_addr)), 16))
+		routers = append(routers, r)
+	}
+	return routers, nil
+}
+
+// Prefixes returns the prefix list
+func Prefixes() ([]Prefix, error) {
+	buf, err := isyscall.Sysctl(icmp6Mib(constants.ICMPV6CTL_ND6_PRLIST))
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []Prefix
+	for len(buf) >= C.sizeof_struct_in6_prefix {
+		// Entries are packed, so copy them out before access
+		var p C.struct_in6_prefix
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&p)), C.sizeof_struct_in6_prefix), buf)
+		buf = buf[C.sizeof_struct_in6_prefix:]
+
+		pr := Prefix{
+			PrefixLen:  int(p.prefixlen),
+			OnLink:     C.prefix_onlink(&p) != 0,
+			Autonomous: C.prefix_autonomous(&p) != 0,
+			VLTime:     uint32(p.vltime),
+			PLTime:     uint32(p.pltime),
+			Expire:     int64(p.expire),
+			Flags:      uint32(p.flags),
+			Index:      int(p.if_index),
+		}
+		copy(pr.Addr[:], unsafe.Slice((*byte)(unsafe.Pointer(&p.prefix.sin6_addr)), 16))
+
+		// The advertising routers follow the prefix as sockaddr_in6s
+		n := int(p.advrtrs)
+		if len(buf) < n*C.sizeof_struct_sockaddr_in6 {
+			return nil, fmt.Errorf("prefix list truncated")
+		}
+		for i := 0; i < n; i++ {
+			var sin6 C.struct_sockaddr_in6
+			copy(unsafe.Slice((*byte)(unsafe.Pointer(&sin6)), C.sizeof_struct_sockaddr_in6), buf)
+			buf = buf[C.sizeof_struct_sockaddr_in6:]
+
+			r := DefRouter{ZoneID: uint32(sin6.sin6_scope_id)}
+			copy(r.Addr[:], unsafe.Slice((*byte)(unsafe.Pointer(&sin6.sin6_addr)), 16))
+			pr.Advertisers = append(pr.Advertisers, r)
+		}
+		prefixes = append(prefixes, pr)
+	}
+	return prefixes, nil
+}
+
+// FlushDefaultRouters removes all entries from the default router list
+func FlushDefaultRouters() error {
+	return flush(constants.SIOCSRTRFLUSH_IN6)
+}
+
+// FlushPrefixes removes all entries from the prefix list
+func FlushPrefixes() error {
+	return flush(constants.SIOCSPFXFLUSH_IN6)
+}
+
+func flush(req uintptr) error {
+	s, err := isyscall.CreateInet6Socket()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	// The lists are global; ndp(8) passes lo0 as the interface
+	var ifr C.struct_in6_ifreq
+	isyscall.CopyString(unsafe.Pointer(&ifr.ifr_name[0]), "lo0", constants.IFNAMSIZ)
+
+	return isyscall.Ioctl(s.Int(), req, unsafe.Pointer(&ifr))
+}
+
+func icmp6Mib(op int) []int32 {
+	return []int32{constants.CTL_NET, constants.AF_INET6, constants.IPPROTO_ICMPV6, int32(op)}
+}
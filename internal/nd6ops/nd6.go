@@ -0,0 +1,91 @@
+//go:build freebsd
+// +build freebsd
+
+package nd6ops
+
+/*
+#include <sys/types.h>
+#include <sys/socket.h>
+#include <net/if.h>
+#include <netinet/in.h>
+#include <netinet6/in6_var.h>
+#include <netinet6/nd6.h>
+#include <string.h>
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// IfInfo represents per-interface Neighbor Discovery state (struct nd_ifinfo)
+type IfInfo struct {
+	LinkMTU       uint32
+	MaxMTU        uint32
+	BaseReachable uint32 // msec
+	Reachable     uint32 // sec
+	Retrans       uint32 // msec
+	Flags         uint32
+	CurHopLimit   uint8
+}
+
+// Get returns the Neighbor Discovery state of an interface
+func Get(name string) (IfInfo, error) {
+	s, err := isyscall.CreateInet6Socket()
+	if err != nil {
+		return IfInfo{}, err
+	}
+	defer s.Close()
+
+	if len(name) >= constants.IFNAMSIZ {
+		return IfInfo{}, isyscall.NewValidationError("name", name, "interface name too long")
+	}
+
+	var req C.struct_in6_ndireq
+	isyscall.CopyString(unsafe.Pointer(&req.ifname[0]), name, constants.IFNAMSIZ)
+
+	if err := isyscall.Ioctl(s.Int(), constants.SIOCGIFINFO_IN6, unsafe.Pointer(&req)); err != nil {
+		return IfInfo{}, err
+	}
+
+	return IfInfo{
+		LinkMTU:       uint32(req.ndi.linkmtu),
+		MaxMTU:        uint32(req.ndi.maxmtu),
+		BaseReachable: uint32(req.ndi.basereachable),
+		Reachable:     uint32(req.ndi.reachable),
+		Retrans:       uint32(req.ndi.retrans),
+		Flags:         uint32(req.ndi.flags),
+		CurHopLimit:   uint8(req.ndi.chlim),
+	}, nil
+}
+
+// SetFlags sets or clears Neighbor Discovery flags on an interface
+func SetFlags(name string, flags uint32, set bool) error {
+	s, err := isyscall.CreateInet6Socket()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if len(name) >= constants.IFNAMSIZ {
+		return isyscall.NewValidationError("name", name, "interface name too long")
+	}
+
+	var req C.struct_in6_ndireq
+	isyscall.CopyString(unsafe.Pointer(&req.ifname[0]), name, constants.IFNAMSIZ)
+
+	// Read-modify-write, as ifconfig(8) does
+	if err := isyscall.Ioctl(s.Int(), constants.SIOCGIFINFO_IN6, unsafe.Pointer(&req)); err != nil {
+		return err
+	}
+
+	if set {
+		req.ndi.flags |= C.uint32_t(flags)
+	} else {
+		req.ndi.flags &^= C.uint32_t(flags)
+	}
+
+	return isyscall.Ioctl(s.Int(), constants.SIOCSIFINFO_IN6, unsafe.Pointer(&req))
+}
@@ -0,0 +1,106 @@
+//go:build freebsd
+// +build freebsd
+
+package routing
+
+import (
+	"errors"
+	"net"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// LLEntry is a link-layer table entry (ARP or IPv6 neighbor cache)
+type LLEntry struct {
+	IP     net.IP
+	ZoneID uint32 // sin6_scope_id for IPv6 link-local entries
+	LLAddr net.HardwareAddr
+	Index  int
+	Flags  int
+	Expire uint64 // rmx_expire, 0 for static entries
+	State  int    // Neighbor cache state (rmx_state), IPv6 only
+	Asked  int    // Number of unanswered requests (rmx_pksent)
+}
+
+// ListLL returns the link-layer table entries of an address family
+func ListLL(af int) ([]LLEntry, error) {
+	msgs, err := Dump(af, constants.NET_RT_FLAGS, constants.RTF_LLDATA, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LLEntry
+	for _, m := range msgs {
+		dst := m.Addrs[constants.RTAX_DST]
+		if dst == nil || dst.Family() != af {
+			continue
+		}
+
+		e := LLEntry{
+			IP:     SockaddrIP(dst),
+			Index:  m.Index,
+			Flags:  m.Flags,
+			Expire: m.Metrics.Expire,
+			State:  int(int32(m.Metrics.Weight)),
+			Asked:  int(m.Metrics.Pksent),
+		}
+		if sa6, ok := dst.(*Inet6Addr); ok {
+			e.ZoneID = sa6.ZoneID
+		}
+		if sdl, ok := m.Addrs[constants.RTAX_GATEWAY].(*LinkAddr); ok {
+			if sdl.Index != 0 {
+				e.Index = sdl.Index
+			}
+			if len(sdl.Addr) > 0 {
+				e.LLAddr = net.HardwareAddr(sdl.Addr)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// AddLL adds a link-layer table entry.
+//
+// flags may include RTF_ANNOUNCE for published (proxy) entries. A non-zero
+// expire makes the entry temporary.
+func AddLL(dst Sockaddr, lladdr net.HardwareAddr, ifindex, flags int, expire uint64) error {
+	m := &Message{
+		Type:  constants.RTM_ADD,
+		Index: ifindex,
+		Flags: constants.RTF_HOST | constants.RTF_STATIC | constants.RTF_LLDATA | flags,
+	}
+	if expire != 0 {
+		m.Inits = constants.RTV_EXPIRE
+		m.Metrics.Expire = expire
+	}
+	m.Addrs[constants.RTAX_DST] = dst
+	m.Addrs[constants.RTAX_GATEWAY] = &LinkAddr{
+		Index: ifindex,
+		Type:  constants.IFT_ETHER,
+		Addr:  lladdr,
+	}
+
+	return Send(m)
+}
+
+// DelLL deletes a link-layer table entry.
+//
+// This operation is idempotent - returns nil if the entry doesn't exist.
+func DelLL(dst Sockaddr, ifindex int) error {
+	m := &Message{
+		Type:  constants.RTM_DELETE,
+		Index: ifindex,
+		Flags: constants.RTF_HOST | constants.RTF_LLDATA,
+	}
+	// The kernel finds the interface's table through the gateway's index
+	m.Addrs[constants.RTAX_DST] = dst
+	m.Addrs[constants.RTAX_GATEWAY] = &LinkAddr{Index: ifindex}
+
+	err := Send(m)
+	if errors.Is(err, isyscall.ErrNotFound) {
+		return nil // Idempotent
+	}
+	return err
+}
@@ -0,0 +1,41 @@
+//go:build freebsd
+// +build freebsd
+
+package routing
+
+import (
+	"github.com/zombocoder/go-freebsd-ifc/rtmsg"
+)
+
+// Routing messages and sockaddrs are encoded by the rtmsg package
+type (
+	// Message is a routing socket message with a struct rt_msghdr
+	Message = rtmsg.RouteMessage
+	// Metrics mirrors struct rt_metrics
+	Metrics = rtmsg.Metrics
+	// Sockaddr is a socket address carried in a routing message
+	Sockaddr = rtmsg.Sockaddr
+	// Inet4Addr is a struct sockaddr_in
+	Inet4Addr = rtmsg.Inet4Addr
+	// Inet6Addr is a struct sockaddr_in6
+	Inet6Addr = rtmsg.Inet6Addr
+	// LinkAddr is a struct sockaddr_dl
+	LinkAddr = rtmsg.LinkAddr
+)
+
+// ParseMessages decodes a buffer of routing messages, as returned by a
+// routing sysctl or read from a routing socket. Messages that do not carry
+// a struct rt_msghdr (interface and address announcements) are skipped.
+func ParseMessages(b []byte) ([]*Message, error) {
+	all, err := rtmsg.ParseMessages(b)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*Message
+	for _, m := range all {
+		if rm, ok := m.(*Message); ok {
+			msgs = append(msgs, rm)
+		}
+	}
+	return msgs, nil
+}
@@ -0,0 +1,80 @@
+//go:build freebsd
+// +build freebsd
+
+package routing
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/rtmsg"
+)
+
+// Listener receives the routing messages the kernel broadcasts to routing
+// sockets
+type Listener struct {
+	f   *os.File
+	buf []byte
+}
+
+// Listen opens a routing socket for the messages of address family af (0
+// for all) and routing table fib (< 0 for the process's table).
+//
+// If types is not empty, the kernel only delivers messages of these RTM_*
+// types (ROUTE_MSGFILTER).
+func Listen(af, fib int, types []rtmsg.Type) (*Listener, error) {
+	s, err := isyscall.CreateRouteSocketFamily(af)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setFIB(s, fib); err != nil {
+		s.Close()
+		return nil, err
+	}
+	if len(types) > 0 {
+		var mask uint32
+		for _, t := range types {
+			mask |= 1 << uint(t)
+		}
+		if err := syscall.SetsockoptInt(s.Int(), constants.PF_ROUTE, constants.ROUTE_MSGFILTER, int(mask)); err != nil {
+			s.Close()
+			return nil, mapError(err)
+		}
+	}
+	// A non-blocking descriptor lets Close interrupt a pending Read
+	if err := syscall.SetNonblock(s.Int(), true); err != nil {
+		s.Close()
+		return nil, mapError(err)
+	}
+
+	return &Listener{
+		f:   os.NewFile(uintptr(s.Int()), "route"),
+		buf: make([]byte, os.Getpagesize()),
+	}, nil
+}
+
+// Read blocks until the kernel sends routing messages and returns them.
+// Messages that report a failed request (rtm_errno set) are included.
+func (l *Listener) Read() ([]*Message, error) {
+	for {
+		n, err := l.f.Read(l.buf)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := ParseMessages(l.buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		if len(msgs) > 0 {
+			return msgs, nil
+		}
+	}
+}
+
+// Close closes the socket; a pending Read returns os.ErrClosed.
+func (l *Listener) Close() error {
+	return l.f.Close()
+}
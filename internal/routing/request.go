@@ -0,0 +1,151 @@
+//go:build freebsd
+// +build freebsd
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// replyTimeout bounds the wait for the kernel's answer to a request
+const replyTimeout = 5 * time.Second
+
+// seq numbers the requests of this process, so replies can be matched
+var seq atomic.Int32
+
+// ReplyError is a request the kernel rejected. errors.Is matches it
+// against both the errno and the mapped error, such as ErrExists.
+type ReplyError struct {
+	Errno syscall.Errno // rtm_errno of the reply, or the error of the write
+	Err   error         // Mapped error
+}
+
+func (e *ReplyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ReplyError) Unwrap() []error {
+	return []error{e.Err, e.Errno}
+}
+
+func replyError(errno syscall.Errno) error {
+	return &ReplyError{Errno: errno, Err: mapError(errno)}
+}
+
+// Request writes a routing message and returns the kernel's reply.
+//
+// The socket is switched to fib first unless fib is negative. Each request
+// gets a sequence number unique within the process, and the reply is the
+// message with this process's pid and that number, so concurrent requests
+// never take each other's replies.
+//
+// If the kernel rejects the request, the error is a *ReplyError and the
+// returned message is the reply, or the request if the write already
+// failed, with Errno set.
+func Request(m *Message, fib int) (*Message, error) {
+	s, err := isyscall.CreateRouteSocketFamily(0)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	if err := setFIB(s, fib); err != nil {
+		return nil, err
+	}
+	tv := syscall.NsecToTimeval(replyTimeout.Nanoseconds())
+	if err := syscall.SetsockoptTimeval(s.Int(), syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		return nil, mapError(err)
+	}
+
+	m.Pid = os.Getpid()
+	m.Seq = int(seq.Add(1))
+	msg, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	if err := write(s, msg); err != nil {
+		var re *ReplyError
+		if errors.As(err, &re) {
+			m.Errno = int(re.Errno)
+			return m, err
+		}
+		return nil, err
+	}
+
+	buf := make([]byte, os.Getpagesize())
+	for {
+		n, err := syscall.Read(s.Int(), buf)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err == syscall.EAGAIN {
+			return nil, fmt.Errorf("no reply from routing socket within %s", replyTimeout)
+		}
+		if err != nil {
+			return nil, mapError(err)
+		}
+
+		msgs, err := ParseMessages(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, reply := range msgs {
+			if reply.Pid != m.Pid || reply.Seq != m.Seq || reply.Type != m.Type {
+				continue // Another process's message
+			}
+			if reply.Errno != 0 {
+				return reply, replyError(syscall.Errno(reply.Errno))
+			}
+			return reply, nil
+		}
+	}
+}
+
+// setFIB switches a routing socket to a routing table, unless fib is
+// negative
+func setFIB(s isyscall.Socket, fib int) error {
+	if fib < 0 {
+		return nil
+	}
+	if err := syscall.SetsockoptInt(s.Int(), syscall.SOL_SOCKET, constants.SO_SETFIB, fib); err != nil {
+		return mapError(err)
+	}
+	return nil
+}
+
+// write writes a marshalled message to a routing socket. The kernel
+// reports a rejected request as the error of the write.
+func write(s isyscall.Socket, msg []byte) error {
+	n, err := syscall.Write(s.Int(), msg)
+	if errno, ok := err.(syscall.Errno); ok {
+		return replyError(errno)
+	}
+	if err != nil {
+		return err
+	}
+	if n != len(msg) {
+		return fmt.Errorf("incomplete write to routing socket: %d of %d bytes", n, len(msg))
+	}
+	return nil
+}
+
+// mapError maps errors of routing socket calls. The kernel reports
+// routes that do not exist with ESRCH.
+func mapError(err error) error {
+	errno, ok := err.(syscall.Errno)
+	if !ok {
+		return err
+	}
+	if errno == syscall.ESRCH {
+		return isyscall.ErrNotFound
+	}
+	return isyscall.MapError(errno)
+}
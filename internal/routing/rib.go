@@ -0,0 +1,26 @@
+//go:build freebsd
+// +build freebsd
+
+package routing
+
+import (
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Dump reads a routing table through the CTL_NET.PF_ROUTE sysctl.
+//
+// op is NET_RT_DUMP or NET_RT_FLAGS (with the flags to match in arg).
+// af 0 dumps every address family. fib < 0 selects the process's FIB.
+func Dump(af, op, arg, fib int) ([]*Message, error) {
+	mib := []int32{constants.CTL_NET, constants.PF_ROUTE, 0, int32(af), int32(op), int32(arg)}
+	if fib >= 0 {
+		mib = append(mib, int32(fib))
+	}
+
+	buf, err := isyscall.Sysctl(mib)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMessages(buf)
+}
@@ -3,170 +3,155 @@
 
 package routing
 
-/*
-#include <sys/types.h>
-#include <sys/socket.h>
-#include <net/if.h>
-#include <net/route.h>
-#include <netinet/in.h>
-#include <string.h>
-*/
-import "C"
 import (
-	"bytes"
-	"encoding/binary"
-	"fmt"
+	"errors"
 	"net"
-	"syscall"
-	"unsafe"
 
 	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
 	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/rtmsg"
 )
 
-type rtMsghdr struct {
-	msglen  uint16
-	version uint8
-	msgtype uint8
-	index   uint16
-	flags   int32
-	addrs   int32
-	pid     int32
-	seq     int32
-	errno   int32
-	use     int32
-	inits   uint32
-	rmx     [14]uint32
+// RouteSpec describes a route to add, delete or change
+type RouteSpec struct {
+	Dst     *net.IPNet
+	Gateway Sockaddr // IP gateway, link-layer sockaddr for routes directly out of an interface, or nil
+	Ifindex int
+	Flags   int     // Additional RTF_* flags, such as RTF_BLACKHOLE
+	FIB     int     // Routing table, < 0 for the process's table
+	Inits   uint64  // RTV_* flags of the metrics to set
+	Metrics Metrics // Values of the metrics selected by Inits
+	Strict  bool    // Report existing routes on add and missing ones on delete
 }
 
-// ModifyRoute adds or deletes a route (supports IPv4 and IPv6)
-func ModifyRoute(add bool, dst *net.IPNet, gw net.IP, ifindex int) error {
-	s, err := isyscall.CreateRouteSocket()
-	if err != nil {
-		return err
-	}
-	defer s.Close()
-
-	var op int
-	if add {
-		op = constants.RTM_ADD
-	} else {
-		op = constants.RTM_DELETE
-	}
-
-	buf := new(bytes.Buffer)
-
-	flags := constants.RTF_UP | constants.RTF_STATIC
-	if gw != nil {
+// ModifyRoute adds, deletes or changes a route (supports IPv4 and IPv6)
+// and returns the kernel's reply, which holds the route as the kernel
+// resolved it.
+//
+// op is RTM_ADD, RTM_DELETE or RTM_CHANGE. Adding an existing route and
+// deleting a missing one succeed unless spec.Strict is set, in which case
+// they return ErrExists and ErrNotFound; the returned message carries the
+// errno either way. Changing a missing route returns ErrNotFound.
+func ModifyRoute(op int, spec RouteSpec) (*Message, error) {
+	flags := spec.Flags | constants.RTF_UP | constants.RTF_STATIC
+	gw := spec.Gateway
+	switch gw.(type) {
+	case *Inet4Addr, *Inet6Addr:
 		flags |= constants.RTF_GATEWAY
+	case nil:
+		if spec.Dst.IP.To4() == nil {
+			gw = IPSockaddr(net.IPv6zero)
+		} else {
+			gw = IPSockaddr(net.IPv4zero)
+		}
 	}
-	ones, bits := dst.Mask.Size()
+	ones, bits := spec.Dst.Mask.Size()
 	if ones == bits {
 		flags |= constants.RTF_HOST
 	}
 
-	// Detect IPv4 vs IPv6
-	isIPv6 := dst.IP.To4() == nil
-	var family int32
-	if isIPv6 {
-		family = constants.AF_INET6
-	} else {
-		family = constants.AF_INET
+	m := &Message{
+		Type:    rtmsg.Type(op),
+		Index:   spec.Ifindex,
+		Flags:   flags,
+		Inits:   spec.Inits,
+		Metrics: spec.Metrics,
 	}
-
-	hdr := rtMsghdr{
-		msglen:  0,
-		version: uint8(constants.RTM_VERSION),
-		msgtype: uint8(op),
-		index:   uint16(ifindex),
-		flags:   int32(flags),
-		addrs:   constants.RTA_DST | constants.RTA_GATEWAY | constants.RTA_NETMASK,
-		pid:     0,
-		seq:     1,
+	// Link-local destinations are scoped to the interface
+	m.Addrs[constants.RTAX_DST] = IPSockaddrZone(spec.Dst.IP, spec.Ifindex)
+	m.Addrs[constants.RTAX_GATEWAY] = gw
+	m.Addrs[constants.RTAX_NETMASK] = MaskSockaddr(spec.Dst.Mask)
+	if op == constants.RTM_CHANGE && spec.Ifindex != 0 {
+		// The kernel only moves a route to another interface when asked
+		// through RTA_IFP
+		m.Addrs[constants.RTAX_IFP] = &LinkAddr{Index: spec.Ifindex}
 	}
 
-	binary.Write(buf, binary.LittleEndian, hdr)
-
-	if isIPv6 {
-		writeSockaddr(buf, dst.IP, constants.AF_INET6)
-	} else {
-		writeSockaddr(buf, dst.IP.To4(), constants.AF_INET)
+	reply, err := Request(m, spec.FIB)
+	if spec.Strict {
+		return reply, err
 	}
-
-	if gw != nil {
-		if isIPv6 {
-			writeSockaddr(buf, gw, constants.AF_INET6)
-		} else {
-			writeSockaddr(buf, gw.To4(), constants.AF_INET)
-		}
-	} else {
-		if isIPv6 {
-			writeSockaddr(buf, net.IPv6zero, constants.AF_INET6)
-		} else {
-			writeSockaddr(buf, net.IPv4zero, constants.AF_INET)
-		}
+	if op == constants.RTM_ADD && errors.Is(err, isyscall.ErrExists) {
+		return reply, nil // Idempotent
 	}
+	if op == constants.RTM_DELETE && errors.Is(err, isyscall.ErrNotFound) {
+		return reply, nil // Idempotent
+	}
+	return reply, err
+}
 
-	writeSockaddrMask(buf, dst.Mask, family)
-
-	msgBytes := buf.Bytes()
-	msglen := uint16(len(msgBytes))
-	binary.LittleEndian.PutUint16(msgBytes[0:2], msglen)
+// Send writes a routing message to a routing socket and waits for the
+// kernel's reply.
+//
+// The kernel reports EEXIST and ESRCH for routes that already exist or do
+// not exist; these map to ErrExists and ErrNotFound.
+func Send(m *Message) error {
+	_, err := Request(m, -1)
+	return err
+}
 
-	n, err := syscall.Write(s.Int(), msgBytes)
-	if err != nil {
-		if add && err == syscall.EEXIST {
-			return nil // Idempotent
-		}
-		if !add && (err == syscall.ESRCH || err == syscall.ENOENT) {
-			return nil // Idempotent
-		}
-		return isyscall.MapError(err.(syscall.Errno))
+// IPSockaddr converts an IPv4 or IPv6 address to a sockaddr
+func IPSockaddr(ip net.IP) Sockaddr {
+	if ip4 := ip.To4(); ip4 != nil {
+		sa := &Inet4Addr{}
+		copy(sa.IP[:], ip4)
+		return sa
 	}
+	sa := &Inet6Addr{}
+	copy(sa.IP[:], ip.To16())
+	return sa
+}
 
-	if n != len(msgBytes) {
-		return fmt.Errorf("incomplete write to routing socket: %d of %d bytes", n, len(msgBytes))
+// IPSockaddrZone converts an address to a sockaddr, setting the zone of
+// link-local IPv6 addresses to an interface index
+func IPSockaddrZone(ip net.IP, zone int) Sockaddr {
+	sa := IPSockaddr(ip)
+	if sa6, ok := sa.(*Inet6Addr); ok && (ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()) {
+		sa6.ZoneID = uint32(zone)
 	}
-
-	return nil
+	return sa
 }
 
-func writeSockaddr(buf *bytes.Buffer, ip net.IP, family int32) {
-	for buf.Len()%int(unsafe.Sizeof(C.long(0))) != 0 {
-		buf.WriteByte(0)
+// MaskSockaddr converts a netmask to a sockaddr
+func MaskSockaddr(mask net.IPMask) Sockaddr {
+	if len(mask) == net.IPv4len {
+		sa := &Inet4Addr{}
+		copy(sa.IP[:], mask)
+		return sa
 	}
+	sa := &Inet6Addr{}
+	copy(sa.IP[:], mask)
+	return sa
+}
 
-	if family == constants.AF_INET {
-		sa := make([]byte, syscall.SizeofSockaddrInet4)
-		sa[0] = byte(syscall.SizeofSockaddrInet4)
-		sa[1] = byte(syscall.AF_INET)
-		copy(sa[4:8], ip.To4())
-		buf.Write(sa)
-	} else if family == constants.AF_INET6 {
-		sa := make([]byte, syscall.SizeofSockaddrInet6)
-		sa[0] = byte(syscall.SizeofSockaddrInet6)
-		sa[1] = byte(syscall.AF_INET6)
-		copy(sa[8:24], ip.To16())
-		buf.Write(sa)
+// SockaddrIP returns the address of an IPv4 or IPv6 sockaddr, or nil
+func SockaddrIP(sa Sockaddr) net.IP {
+	switch sa := sa.(type) {
+	case *Inet4Addr:
+		return net.IPv4(sa.IP[0], sa.IP[1], sa.IP[2], sa.IP[3]).To4()
+	case *Inet6Addr:
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, sa.IP[:])
+		return ip
 	}
+	return nil
 }
 
-func writeSockaddrMask(buf *bytes.Buffer, mask net.IPMask, family int32) {
-	for buf.Len()%int(unsafe.Sizeof(C.long(0))) != 0 {
-		buf.WriteByte(0)
+// DstPrefix returns the destination network of a route message, using the
+// netmask if present. Host routes and routes without a netmask get a full
+// length mask. Returns nil if the destination is not IPv4 or IPv6.
+func DstPrefix(m *Message) *net.IPNet {
+	ip := SockaddrIP(m.Addrs[constants.RTAX_DST])
+	if ip == nil {
+		return nil
 	}
+	bits := len(ip) * 8
 
-	if family == constants.AF_INET {
-		sa := make([]byte, syscall.SizeofSockaddrInet4)
-		sa[0] = byte(syscall.SizeofSockaddrInet4)
-		sa[1] = byte(syscall.AF_INET)
-		copy(sa[4:8], mask)
-		buf.Write(sa)
-	} else if family == constants.AF_INET6 {
-		sa := make([]byte, syscall.SizeofSockaddrInet6)
-		sa[0] = byte(syscall.SizeofSockaddrInet6)
-		sa[1] = byte(syscall.AF_INET6)
-		copy(sa[8:24], mask)
-		buf.Write(sa)
+	mask := net.CIDRMask(bits, bits)
+	if m.Flags&constants.RTF_HOST == 0 {
+		if sa := SockaddrIP(m.Addrs[constants.RTAX_NETMASK]); sa != nil && len(sa) == len(ip) {
+			mask = net.IPMask(sa)
+		}
 	}
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
 }
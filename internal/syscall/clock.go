@@ -0,0 +1,17 @@
+//go:build freebsd
+// +build freebsd
+
+package syscall
+
+/*
+#include <time.h>
+*/
+import "C"
+
+// Uptime returns the CLOCK_MONOTONIC time in seconds, the time base of
+// ARP entry expiry times
+func Uptime() int64 {
+	var ts C.struct_timespec
+	C.clock_gettime(C.CLOCK_MONOTONIC, &ts)
+	return int64(ts.tv_sec)
+}
@@ -34,6 +34,9 @@ var (
 	// ErrAddressInUse indicates the address is already in use
 	ErrAddressInUse = errors.New("address already in use")
 
+	// ErrDuplicateAddress indicates IPv6 Duplicate Address Detection failed
+	ErrDuplicateAddress = errors.New("duplicate address detected")
+
 	// ErrSyscall is a generic syscall error wrapper
 	ErrSyscall = errors.New("syscall error")
 )
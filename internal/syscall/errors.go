@@ -8,7 +8,9 @@ import (
 	"syscall"
 )
 
-// MapErrno maps a syscall errno to a typed error
+// MapErrno maps a syscall errno to a typed error. Errnos without a typed
+// error are wrapped together with ErrSyscall, so errors.Is also matches the
+// errno itself.
 func mapErrno(err syscall.Errno) error {
 	switch err {
 	case syscall.EPERM, syscall.EACCES:
@@ -28,7 +30,8 @@ func mapErrno(err syscall.Errno) error {
 	case syscall.EADDRINUSE:
 		return ErrAddressInUse
 	default:
-		return fmt.Errorf("%w: %v", ErrSyscall, err)
+		// Keep the errno for callers that handle it specially
+		return fmt.Errorf("%w: %w", ErrSyscall, err)
 	}
 }
 
@@ -0,0 +1,25 @@
+//go:build freebsd
+// +build freebsd
+
+package syscall
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+)
+
+// TestMapError tests that unmapped errnos stay visible to errors.Is
+func TestMapError(t *testing.T) {
+	err := MapError(syscall.ESRCH)
+	if !errors.Is(err, ErrSyscall) {
+		t.Errorf("MapError(ESRCH) = %v, should match ErrSyscall", err)
+	}
+	if !errors.Is(err, syscall.ESRCH) {
+		t.Errorf("MapError(ESRCH) = %v, should match ESRCH", err)
+	}
+
+	if err := MapError(syscall.ENOENT); !errors.Is(err, ErrNotFound) {
+		t.Errorf("MapError(ENOENT) = %v, want ErrNotFound", err)
+	}
+}
@@ -22,6 +22,10 @@ static int create_route_socket() {
     return socket(PF_ROUTE, SOCK_RAW, AF_INET);
 }
 
+static int create_route_socket_af(int af) {
+    return socket(PF_ROUTE, SOCK_RAW, af);
+}
+
 static int get_errno() {
     return errno;
 }
@@ -65,6 +69,16 @@ func CreateRouteSocket() (Socket, error) {
 	return s, nil
 }
 
+// CreateRouteSocketFamily creates a PF_ROUTE socket that receives the
+// routing messages of one address family, or of all families for af 0
+func CreateRouteSocketFamily(af int) (Socket, error) {
+	s := Socket(C.create_route_socket_af(C.int(af)))
+	if s < 0 {
+		return -1, mapErrno(syscall.Errno(C.get_errno()))
+	}
+	return s, nil
+}
+
 // Close closes the socket
 func (s Socket) Close() {
 	C.close_fd(C.int(s))
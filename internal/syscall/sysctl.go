@@ -0,0 +1,226 @@
+//go:build freebsd
+// +build freebsd
+
+package syscall
+
+/*
+#include <sys/types.h>
+#include <sys/sysctl.h>
+#include <stdlib.h>
+#include <string.h>
+#include <errno.h>
+
+static int get_errno() {
+    return errno;
+}
+
+// oid_kind reads the CTLTYPE and flags of a sysctl, like sysctl(8)
+static int oid_kind(const char *name, u_int *kind) {
+    int mib[CTL_MAXNAME + 2];
+    size_t miblen = CTL_MAXNAME;
+    char buf[BUFSIZ];
+    size_t len = sizeof(buf);
+
+    if (sysctlnametomib(name, mib + 2, &miblen) != 0) {
+        return -1;
+    }
+    mib[0] = CTL_SYSCTL;
+    mib[1] = CTL_SYSCTL_OIDFMT;
+    if (sysctl(mib, miblen + 2, buf, &len, NULL, 0) != 0) {
+        return -1;
+    }
+    if (len < sizeof(*kind)) {
+        errno = EINVAL;
+        return -1;
+    }
+    memcpy(kind, buf, sizeof(*kind));
+    return 0;
+}
+*/
+import "C"
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"syscall"
+	"unsafe"
+)
+
+// SysctlInt reads an integer sysctl by name
+func SysctlInt(name string) (int, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	var value C.int
+	size := C.size_t(unsafe.Sizeof(value))
+	if C.sysctlbyname(cname, unsafe.Pointer(&value), &size, nil, 0) != 0 {
+		return 0, mapErrno(syscall.Errno(C.get_errno()))
+	}
+	return int(value), nil
+}
+
+// SetSysctlInt writes an integer sysctl by name
+func SetSysctlInt(name string, v int) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	value := C.int(v)
+	if C.sysctlbyname(cname, nil, nil, unsafe.Pointer(&value), C.size_t(unsafe.Sizeof(value))) != 0 {
+		return mapErrno(syscall.Errno(C.get_errno()))
+	}
+	return nil
+}
+
+// Sysctl reads a sysctl by MIB and returns its raw value
+func Sysctl(mib []int32) ([]byte, error) {
+	cmib := make([]C.int, len(mib))
+	for i, v := range mib {
+		cmib[i] = C.int(v)
+	}
+
+	// The size can grow between the two calls, so retry on ENOMEM
+	for {
+		var size C.size_t
+		if C.sysctl(&cmib[0], C.u_int(len(cmib)), nil, &size, nil, 0) != 0 {
+			return nil, mapErrno(syscall.Errno(C.get_errno()))
+		}
+		if size == 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, size)
+		if C.sysctl(&cmib[0], C.u_int(len(cmib)), unsafe.Pointer(&buf[0]), &size, nil, 0) != 0 {
+			errno := syscall.Errno(C.get_errno())
+			if errno == syscall.ENOMEM {
+				continue
+			}
+			return nil, mapErrno(errno)
+		}
+		return buf[:size], nil
+	}
+}
+
+// SysctlInt64 reads an integer sysctl of any width (int, long, int64).
+// Sysctls of other types return ErrNotSupported.
+func SysctlInt64(name string) (int64, error) {
+	size, signed, err := sysctlIntType(name)
+	if err != nil {
+		return 0, err
+	}
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	var buf [8]byte
+	csize := C.size_t(size)
+	if C.sysctlbyname(cname, unsafe.Pointer(&buf[0]), &csize, nil, 0) != 0 {
+		return 0, mapErrno(syscall.Errno(C.get_errno()))
+	}
+	switch {
+	case size == 4 && signed:
+		return int64(*(*int32)(unsafe.Pointer(&buf[0]))), nil
+	case size == 4:
+		return int64(*(*uint32)(unsafe.Pointer(&buf[0]))), nil
+	}
+	return *(*int64)(unsafe.Pointer(&buf[0])), nil
+}
+
+// SetSysctlInt64 writes an integer sysctl with the width of its type
+func SetSysctlInt64(name string, v int64) error {
+	size, signed, err := sysctlIntType(name)
+	if err != nil {
+		return err
+	}
+
+	var buf [8]byte
+	switch {
+	case size == 4 && signed:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return NewValidationError(name, fmt.Sprintf("%d", v), "out of range for a 32-bit sysctl")
+		}
+		*(*int32)(unsafe.Pointer(&buf[0])) = int32(v)
+	case size == 4:
+		if v < 0 || v > math.MaxUint32 {
+			return NewValidationError(name, fmt.Sprintf("%d", v), "out of range for an unsigned 32-bit sysctl")
+		}
+		*(*uint32)(unsafe.Pointer(&buf[0])) = uint32(v)
+	default:
+		*(*int64)(unsafe.Pointer(&buf[0])) = v
+	}
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	if C.sysctlbyname(cname, nil, nil, unsafe.Pointer(&buf[0]), C.size_t(size)) != 0 {
+		return mapErrno(syscall.Errno(C.get_errno()))
+	}
+	return nil
+}
+
+// sysctlIntType returns the width and signedness of an integer sysctl
+// from its declared type, not from the size of its value, which a string
+// can share
+func sysctlIntType(name string) (size int, signed bool, err error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	var kind C.u_int
+	if C.oid_kind(cname, &kind) != 0 {
+		return 0, false, mapErrno(syscall.Errno(C.get_errno()))
+	}
+	switch kind & C.CTLTYPE {
+	case C.CTLTYPE_INT, C.CTLTYPE_S32:
+		return 4, true, nil
+	case C.CTLTYPE_UINT, C.CTLTYPE_U32:
+		return 4, false, nil
+	case C.CTLTYPE_LONG:
+		return int(unsafe.Sizeof(C.long(0))), true, nil
+	case C.CTLTYPE_ULONG:
+		return int(unsafe.Sizeof(C.ulong(0))), false, nil
+	case C.CTLTYPE_S64, C.CTLTYPE_U64:
+		return 8, true, nil
+	}
+	return 0, false, ErrNotSupported
+}
+
+// SysctlString reads a string sysctl by name
+func SysctlString(name string) (string, error) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	for {
+		var size C.size_t
+		if C.sysctlbyname(cname, nil, &size, nil, 0) != 0 {
+			return "", mapErrno(syscall.Errno(C.get_errno()))
+		}
+		if size == 0 {
+			return "", nil
+		}
+
+		buf := make([]byte, size)
+		if C.sysctlbyname(cname, unsafe.Pointer(&buf[0]), &size, nil, 0) != 0 {
+			errno := syscall.Errno(C.get_errno())
+			if errno == syscall.ENOMEM {
+				continue
+			}
+			return "", mapErrno(errno)
+		}
+		buf = buf[:size]
+		if i := bytes.IndexByte(buf, 0); i >= 0 {
+			buf = buf[:i]
+		}
+		return string(buf), nil
+	}
+}
+
+// SetSysctlString writes a string sysctl by name
+func SetSysctlString(name, v string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cvalue := C.CString(v)
+	defer C.free(unsafe.Pointer(cvalue))
+
+	if C.sysctlbyname(cname, nil, nil, unsafe.Pointer(cvalue), C.size_t(len(v)+1)) != 0 {
+		return mapErrno(syscall.Errno(C.get_errno()))
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+)
+
+// AddFromPrefix generates an address in prefix with method and adds it to
+// an interface.
+//
+// The prefix must be at most 64 bits long; the address is added with the
+// prefix's length. Addresses from Temporary carry FlagTemporary.
+// Returns the address that was added. Requires root privileges.
+//
+// Example:
+//
+//	// Deterministic address for a jail, the same on every run
+//	prefix := netip.MustParsePrefix("2001:db8:1:2::/64")
+//	addr, err := ip.AddFromPrefix("epair5b", prefix, ip.StablePrivacy{Secret: secret})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println("added", addr)
+func AddFromPrefix(iface string, prefix netip.Prefix, method Method) (netip.Addr, error) {
+	mac, err := ifops.HardwareAddr(iface)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("add address from %s to %s: %w", prefix, iface, err)
+	}
+
+	id, err := method.InterfaceID(IIDInput{Prefix: prefix, Interface: iface, MAC: mac})
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("add address from %s to %s: %w", prefix, iface, err)
+	}
+	addr, err := Address(prefix, id)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("add address from %s to %s: %w", prefix, iface, err)
+	}
+
+	var opts Add6Options
+	if _, ok := method.(Temporary); ok {
+		opts.Flags = FlagTemporary
+	}
+	if err := Add6WithOptions(iface, addr.AsSlice(), prefix.Bits(), opts); err != nil {
+		return netip.Addr{}, err
+	}
+	return addr, nil
+}
@@ -0,0 +1,117 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/bpfops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Announcement defaults
+const (
+	DefaultAnnounceCount    = 3
+	DefaultAnnounceInterval = time.Second
+)
+
+// AnnounceOptions configures the announcements sent by AnnounceWithOptions.
+type AnnounceOptions struct {
+	Count    int           // Number of announcements, 0 for DefaultAnnounceCount
+	Interval time.Duration // Delay between announcements, 0 for DefaultAnnounceInterval
+}
+
+// Announce tells the link that addr is now at iface, so switches and
+// neighbors update stale entries after an address moved between hosts or
+// interfaces.
+//
+// IPv4 addresses are announced with gratuitous ARP, IPv6 addresses with
+// unsolicited Neighbor Advertisements to all nodes. The frames are written
+// through BPF, so the interface needs an Ethernet address. Announce sends
+// DefaultAnnounceCount announcements DefaultAnnounceInterval apart and
+// returns after the last one. Requires root privileges.
+//
+// Example:
+//
+//	// Take over a service address from a failed host
+//	addr := netip.MustParseAddr("192.0.2.10")
+//	if err := ip.Add4("em0", addr.AsSlice(), net.CIDRMask(24, 32)); err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := ip.Announce("em0", addr); err != nil {
+//		log.Fatal(err)
+//	}
+func Announce(iface string, addr netip.Addr) error {
+	return AnnounceWithOptions(iface, addr, AnnounceOptions{})
+}
+
+// AnnounceWithOptions is Announce with a configurable repeat count and
+// interval.
+//
+// IPv6 addresses must not be announced while Duplicate Address Detection
+// is running; wait for it with WaitDAD first. The Neighbor Advertisements
+// carry the router flag when IPv6 forwarding is enabled.
+//
+// Example:
+//
+//	err := ip.AnnounceWithOptions("em0", addr, ip.AnnounceOptions{
+//		Count:    5,
+//		Interval: 200 * time.Millisecond,
+//	})
+func AnnounceWithOptions(iface string, addr netip.Addr, opts AnnounceOptions) error {
+	if opts.Count < 0 {
+		return isyscall.NewValidationError("Count", fmt.Sprintf("%d", opts.Count), "must not be negative")
+	}
+	if opts.Interval < 0 {
+		return isyscall.NewValidationError("Interval", opts.Interval.String(), "must not be negative")
+	}
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsMulticast() {
+		return isyscall.NewValidationError("addr", addr.String(), "not a unicast address")
+	}
+	if opts.Count == 0 {
+		opts.Count = DefaultAnnounceCount
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultAnnounceInterval
+	}
+
+	mac, err := ifops.HardwareAddr(iface)
+	if err != nil {
+		return fmt.Errorf("announce %s on %s: %w", addr, iface, err)
+	}
+	if len(mac) != 6 {
+		return isyscall.NewValidationError("iface", iface, "interface has no Ethernet address")
+	}
+
+	var frame []byte
+	if addr.Unmap().Is4() {
+		frame, err = gratuitousARP(mac, addr)
+	} else {
+		forwarding, _ := isyscall.SysctlInt(constants.SysctlIP6Forwarding)
+		frame, err = unsolicitedNA(mac, addr, forwarding != 0)
+	}
+	if err != nil {
+		return isyscall.NewValidationError("addr", addr.String(), err.Error())
+	}
+
+	w, err := bpfops.Open(iface)
+	if err != nil {
+		return fmt.Errorf("announce %s on %s: %w", addr, iface, err)
+	}
+	defer w.Close()
+
+	for i := 0; i < opts.Count; i++ {
+		if i > 0 {
+			time.Sleep(opts.Interval)
+		}
+		if err := w.Write(frame); err != nil {
+			return fmt.Errorf("announce %s on %s: %w", addr, iface, err)
+		}
+	}
+	return nil
+}
@@ -4,9 +4,13 @@
 package ip
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"net/netip"
+	"time"
 
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
 	"github.com/zombocoder/go-freebsd-ifc/internal/ipaddr"
 	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
 )
@@ -16,15 +20,54 @@ import (
 // Returns a validation error if the IP is not IPv4 or the mask is invalid.
 // This operation is idempotent - returns nil if the address already exists.
 func Add4(iface string, ip net.IP, mask net.IPMask) error {
+	return Add4WithOptions(iface, ip, mask, Add4Options{})
+}
+
+// Add4WithOptions adds an IPv4 address to an interface with extra options.
+//
+// With opts.Vhid set, the address is a CARP virtual address; the vhid must
+// already be configured on the interface (see package carp). With
+// opts.Strict set, the address is first validated with Check. With
+// opts.Announce set, the address is announced with gratuitous ARP after it
+// was added. If the announcement fails, the address remains configured.
+//
+// Example:
+//
+//	// ifconfig em0 inet 192.0.2.1/24 vhid 1
+//	err := ip.Add4WithOptions("em0", net.ParseIP("192.0.2.1"), net.CIDRMask(24, 32),
+//		ip.Add4Options{Vhid: 1})
+func Add4WithOptions(iface string, ip net.IP, mask net.IPMask, opts Add4Options) error {
 	if ip.To4() == nil {
 		return isyscall.NewValidationError("ip", ip.String(), "not an IPv4 address")
 	}
 	if len(mask) != 4 {
 		return isyscall.NewValidationError("mask", fmt.Sprintf("%v", mask), "invalid IPv4 mask length")
 	}
-	if err := ipaddr.Add4(iface, ip.To4(), mask); err != nil {
+	if err := validateVhid(opts.Vhid); err != nil {
+		return err
+	}
+	if opts.Strict {
+		ones, _ := mask.Size()
+		if err := checkStrict(iface, ip, ones); err != nil {
+			return err
+		}
+	}
+	if err := ipaddr.Add4WithOptions(iface, ip.To4(), mask, ipaddr.Add4Options{Vhid: opts.Vhid}); err != nil {
 		return fmt.Errorf("add IPv4 %s/%s to %s: %w", ip, mask, iface, err)
 	}
+	if opts.Announce != nil {
+		addr, _ := netip.AddrFromSlice(ip.To4())
+		if err := AnnounceWithOptions(iface, addr, *opts.Announce); err != nil {
+			return fmt.Errorf("announce %s on %s: %w", addr, iface, err)
+		}
+	}
+	return nil
+}
+
+func validateVhid(vhid int) error {
+	if vhid < 0 || vhid > constants.CARP_MAXVHID {
+		return isyscall.NewValidationError("vhid", fmt.Sprintf("%d", vhid), "must be between 1 and 255, or 0 for none")
+	}
 	return nil
 }
 
@@ -50,24 +93,123 @@ func Del4(iface string, ip net.IP, mask net.IPMask) error {
 // Returns a validation error if the IP is not IPv6 or prefixLen is invalid.
 // This operation is idempotent - returns nil if the address already exists.
 func Add6(iface string, ip net.IP, prefixLen int) error {
-	if ip.To4() != nil {
+	return Add6WithOptions(iface, ip, prefixLen, Add6Options{})
+}
+
+// Add6WithOptions adds an IPv6 address to an interface with explicit
+// lifetimes and address flags.
+//
+// A zero ValidLifetime is infinite; a zero PreferredLifetime equals the
+// valid lifetime. Only FlagAnycast, FlagPreferSource, FlagNoDAD,
+// FlagDeprecated, FlagAutoconf and FlagTemporary may be set; the other flags
+// are maintained by the kernel. Adding an existing address updates its
+// lifetimes and flags.
+//
+// If opts.WaitDAD is set, the call returns only once the address is usable,
+// or with ErrDuplicateAddress if Duplicate Address Detection failed.
+// With opts.Strict set, the address is first validated with Check. With
+// opts.Announce set, the address is announced with unsolicited Neighbor
+// Advertisements once it is usable. If waiting for DAD or the announcement
+// fails, the address remains configured.
+//
+// Example:
+//
+//	// Keep an old address during renumbering, but stop using it as source
+//	err := ip.Add6WithOptions("em0", old, 64, ip.Add6Options{
+//		ValidLifetime: 2 * time.Hour,
+//		Flags:         ip.FlagDeprecated,
+//	})
+func Add6WithOptions(iface string, ip net.IP, prefixLen int, opts Add6Options) error {
+	if ip.To16() == nil || ip.To4() != nil {
 		return isyscall.NewValidationError("ip", ip.String(), "not an IPv6 address")
 	}
 	if prefixLen < 0 || prefixLen > 128 {
 		return isyscall.NewValidationError("prefixLen", fmt.Sprintf("%d", prefixLen), "must be between 0 and 128")
 	}
-	if err := ipaddr.Add6(iface, ip, prefixLen); err != nil {
+	if opts.Flags&^settableAddrFlags != 0 {
+		return isyscall.NewValidationError("flags", opts.Flags.String(), "flags are maintained by the kernel")
+	}
+	vltime, pltime, err := lifetimes(opts)
+	if err != nil {
+		return err
+	}
+	if err := validateVhid(opts.Vhid); err != nil {
+		return err
+	}
+	if opts.Strict {
+		if err := checkStrict(iface, ip, prefixLen); err != nil {
+			return err
+		}
+	}
+
+	err = ipaddr.Add6WithOptions(iface, ip, prefixLen, ipaddr.Add6Options{
+		ValidLifetime:     vltime,
+		PreferredLifetime: pltime,
+		Flags:             int(opts.Flags),
+		Vhid:              opts.Vhid,
+	})
+	if err != nil {
 		return fmt.Errorf("add IPv6 %s/%d to %s: %w", ip, prefixLen, iface, err)
 	}
+
+	if opts.WaitDAD > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.WaitDAD)
+		defer cancel()
+		if err := WaitDAD(ctx, iface, ip); err != nil {
+			return err
+		}
+	}
+	if opts.Announce != nil {
+		addr, _ := netip.AddrFromSlice(ip.To16())
+		if err := AnnounceWithOptions(iface, addr, *opts.Announce); err != nil {
+			return fmt.Errorf("announce %s on %s: %w", addr, iface, err)
+		}
+	}
 	return nil
 }
 
+const settableAddrFlags = FlagAnycast | FlagPreferSource | FlagNoDAD | FlagDeprecated | FlagAutoconf | FlagTemporary
+
+// lifetimes returns the valid and preferred lifetimes of opts in seconds.
+// A zero PreferredLifetime follows the valid lifetime.
+func lifetimes(opts Add6Options) (vltime, pltime uint32, err error) {
+	vltime, err = lifetimeSeconds("ValidLifetime", opts.ValidLifetime)
+	if err != nil {
+		return 0, 0, err
+	}
+	if opts.PreferredLifetime == 0 {
+		return vltime, vltime, nil
+	}
+	pltime, err = lifetimeSeconds("PreferredLifetime", opts.PreferredLifetime)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pltime > vltime {
+		return 0, 0, isyscall.NewValidationError("PreferredLifetime", opts.PreferredLifetime.String(), "exceeds valid lifetime")
+	}
+	return vltime, pltime, nil
+}
+
+// lifetimeSeconds converts a lifetime to the kernel's representation,
+// mapping zero to ND6_INFINITE_LIFETIME.
+func lifetimeSeconds(field string, d time.Duration) (uint32, error) {
+	switch {
+	case d == 0:
+		return constants.ND6_INFINITE_LIFETIME, nil
+	case d < time.Second:
+		return 0, isyscall.NewValidationError(field, d.String(), "must be zero (infinite) or at least one second")
+	case d/time.Second >= constants.ND6_INFINITE_LIFETIME:
+		return 0, isyscall.NewValidationError(field, d.String(), "too long, use zero for infinite")
+	}
+	return uint32(d / time.Second), nil
+}
+
 // Del6 removes an IPv6 address from an interface.
 //
 // Returns a validation error if the IP is not IPv6 or prefixLen is invalid.
 // This operation is idempotent - returns nil if the address doesn't exist.
 func Del6(iface string, ip net.IP, prefixLen int) error {
-	if ip.To4() != nil {
+	if ip.To16() == nil || ip.To4() != nil {
 		return isyscall.NewValidationError("ip", ip.String(), "not an IPv6 address")
 	}
 	if prefixLen < 0 || prefixLen > 128 {
@@ -4,9 +4,18 @@
 package ip
 
 import (
+	"context"
+	"errors"
 	"net"
+	"net/netip"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/epair"
+	ifc "github.com/zombocoder/go-freebsd-ifc/if"
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
 )
 
 func skipIfNotRoot(t *testing.T) {
@@ -82,6 +91,20 @@ func TestAdd6Del6(t *testing.T) {
 	}
 }
 
+// TestDelMissing tests that deleting an address that was never configured
+// succeeds; the kernel reports it with EADDRNOTAVAIL
+func TestDelMissing(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	if err := Del4("lo0", net.ParseIP("198.51.100.77"), net.CIDRMask(32, 32)); err != nil {
+		t.Errorf("Del4() of a missing address failed: %v", err)
+	}
+	if err := Del6("lo0", net.ParseIP("2001:db8::77"), 128); err != nil {
+		t.Errorf("Del6() of a missing address failed: %v", err)
+	}
+}
+
 // TestInvalidIPv4 tests error handling for invalid IPv4 addresses
 func TestInvalidIPv4(t *testing.T) {
 	skipIfNotRoot(t)
@@ -159,3 +182,437 @@ func TestInvalidPrefixLength(t *testing.T) {
 		t.Error("Add6() should fail with negative prefix length")
 	}
 }
+
+// TestAdd6WithOptions tests adding an IPv6 address with lifetimes and flags
+func TestAdd6WithOptions(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	iface := "lo0"
+	ip := net.ParseIP("fd00:db8::26")
+	opts := Add6Options{
+		ValidLifetime:     time.Hour,
+		PreferredLifetime: 30 * time.Minute,
+		Flags:             FlagNoDAD | FlagPreferSource,
+	}
+
+	if err := Add6WithOptions(iface, ip, 128, opts); err != nil {
+		t.Fatalf("Add6WithOptions() failed: %v", err)
+	}
+	defer Del6(iface, ip, 128)
+
+	// Deprecate the address in place
+	opts.Flags |= FlagDeprecated
+	if err := Add6WithOptions(iface, ip, 128, opts); err != nil {
+		t.Errorf("Add6WithOptions() with FlagDeprecated failed: %v", err)
+	}
+}
+
+// TestAdd6WithOptionsInvalid tests validation of lifetimes and flags
+func TestAdd6WithOptionsInvalid(t *testing.T) {
+	ip := net.ParseIP("fd00:db8::26")
+
+	tests := []struct {
+		name string
+		opts Add6Options
+	}{
+		{"preferred exceeds valid", Add6Options{ValidLifetime: time.Minute, PreferredLifetime: time.Hour}},
+		{"negative lifetime", Add6Options{ValidLifetime: -time.Second}},
+		{"sub-second lifetime", Add6Options{ValidLifetime: time.Millisecond}},
+		{"kernel flag", Add6Options{Flags: FlagTentative}},
+	}
+
+	for _, tt := range tests {
+		err := Add6WithOptions("lo0", ip, 64, tt.opts)
+		if !isyscall.IsValidation(err) {
+			t.Errorf("%s: expected validation error, got %v", tt.name, err)
+		}
+	}
+}
+
+// TestLifetimes tests the conversion of address lifetimes
+func TestLifetimes(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           Add6Options
+		vltime, pltime uint32
+	}{
+		{"infinite", Add6Options{}, constants.ND6_INFINITE_LIFETIME, constants.ND6_INFINITE_LIFETIME},
+		// The Add6WithOptions example: the preferred lifetime follows the valid one
+		{"deprecated with valid lifetime", Add6Options{ValidLifetime: 2 * time.Hour, Flags: FlagDeprecated}, 7200, 7200},
+		{"both", Add6Options{ValidLifetime: time.Hour, PreferredLifetime: 30 * time.Minute}, 3600, 1800},
+		{"finite preferred", Add6Options{PreferredLifetime: time.Hour}, constants.ND6_INFINITE_LIFETIME, 3600},
+	}
+	for _, tt := range tests {
+		vltime, pltime, err := lifetimes(tt.opts)
+		if err != nil {
+			t.Errorf("%s: lifetimes() failed: %v", tt.name, err)
+			continue
+		}
+		if vltime != tt.vltime || pltime != tt.pltime {
+			t.Errorf("%s: lifetimes() = %d, %d, want %d, %d", tt.name, vltime, pltime, tt.vltime, tt.pltime)
+		}
+	}
+}
+
+// TestAddrFlagsString tests the AddrFlags.String method
+func TestAddrFlagsString(t *testing.T) {
+	if s := (FlagAnycast | FlagNoDAD).String(); s != "anycast,no_dad" {
+		t.Errorf("String() = %q, expected %q", s, "anycast,no_dad")
+	}
+	if s := AddrFlags(0).String(); s != "" {
+		t.Errorf("String() of zero flags = %q, expected empty", s)
+	}
+}
+
+// TestWaitDAD tests waiting for Duplicate Address Detection
+func TestWaitDAD(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	iface := "lo0"
+	ip := net.ParseIP("fd00:db8::28")
+
+	err := Add6WithOptions(iface, ip, 128, Add6Options{WaitDAD: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Add6WithOptions() with WaitDAD failed: %v", err)
+	}
+	defer Del6(iface, ip, 128)
+
+	flags, err := Flags6(iface, ip)
+	if err != nil {
+		t.Fatalf("Flags6() failed: %v", err)
+	}
+	if flags&(FlagTentative|FlagDuplicated) != 0 {
+		t.Errorf("Address should be usable after WaitDAD, flags=<%s>", flags)
+	}
+}
+
+// TestWaitDADNotFound tests WaitDAD on an address that is not configured
+func TestWaitDADNotFound(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := WaitDAD(ctx, "lo0", net.ParseIP("fd00:db8::dead"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("WaitDAD() on missing address should return ErrNotFound, got: %v", err)
+	}
+}
+
+// TestAddFromPrefix tests adding a generated stable privacy address
+func TestAddFromPrefix(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	iface := "lo0"
+	prefix := netip.MustParsePrefix("fd00:db8:33::/64")
+	method := StablePrivacy{Secret: []byte("0123456789abcdef")}
+
+	addr, err := AddFromPrefix(iface, prefix, method)
+	if err != nil {
+		t.Fatalf("AddFromPrefix() failed: %v", err)
+	}
+	defer Del6(iface, addr.AsSlice(), 64)
+
+	if !prefix.Contains(addr) {
+		t.Errorf("AddFromPrefix() returned %s outside %s", addr, prefix)
+	}
+
+	// The same inputs give the same address
+	again, err := AddFromPrefix(iface, prefix, method)
+	if err != nil {
+		t.Fatalf("AddFromPrefix() second call failed: %v", err)
+	}
+	if again != addr {
+		t.Errorf("AddFromPrefix() not reproducible: %s != %s", again, addr)
+	}
+
+	// lo0 has no MAC address
+	if _, err := AddFromPrefix(iface, prefix, EUI64{}); err == nil {
+		t.Error("AddFromPrefix() with EUI64 should fail on lo0")
+	}
+}
+
+// TestCheck tests conflict detection against lo0
+func TestCheck(t *testing.T) {
+	// 127.0.0.1/8 is configured on lo0
+	err := Check("nonexistent999", netip.MustParsePrefix("127.0.0.1/8"))
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("Check() should report a conflict with lo0, got: %v", err)
+	}
+	if ve.Kind != ConflictDuplicate || ve.Interface != "lo0" {
+		t.Errorf("Expected duplicate on lo0, got %v", ve)
+	}
+	if !errors.Is(err, isyscall.ErrInvalidArgument) {
+		t.Errorf("Check() error should unwrap to ErrInvalidArgument")
+	}
+	if !isyscall.IsValidation(err) {
+		t.Errorf("Check() error should be a validation error")
+	}
+
+	err = Check("nonexistent999", netip.MustParsePrefix("127.0.0.2/24"))
+	if !errors.As(err, &ve) || ve.Kind != ConflictOverlap {
+		t.Errorf("Expected overlap with lo0, got: %v", err)
+	}
+
+	// Same interface is not a conflict
+	if err := Check("lo0", netip.MustParsePrefix("127.0.0.1/8")); err != nil {
+		t.Errorf("Check() on the owning interface failed: %v", err)
+	}
+}
+
+// TestCheckNetworkBroadcast tests network and broadcast address detection
+func TestCheckNetworkBroadcast(t *testing.T) {
+	tests := []struct {
+		addr string
+		kind ConflictKind
+	}{
+		{"198.51.100.0/24", ConflictNetwork},
+		{"198.51.100.255/24", ConflictBroadcast},
+		{"198.51.100.64/26", ConflictNetwork},
+		{"198.51.100.127/26", ConflictBroadcast},
+		{"2001:db8:ffff::/64", ConflictNetwork},
+	}
+
+	for _, tt := range tests {
+		err := Check("lo0", netip.MustParsePrefix(tt.addr))
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Kind != tt.kind {
+			t.Errorf("Check(%s) = %v, want %s", tt.addr, err, tt.kind)
+		}
+	}
+
+	// Point-to-point and host prefixes have no network or broadcast address
+	for _, addr := range []string{"198.51.100.0/31", "198.51.100.1/31", "198.51.100.7/32", "2001:db8:ffff::/127"} {
+		err := Check("lo0", netip.MustParsePrefix(addr))
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				if e.Kind == ConflictNetwork || e.Kind == ConflictBroadcast {
+					t.Errorf("Check(%s) reported %v", addr, e)
+				}
+			}
+		}
+	}
+}
+
+// TestStrictAdd tests that strict mode refuses conflicting addresses
+func TestStrictAdd(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	err := Add4WithOptions("lo0", net.ParseIP("198.51.100.0"), net.CIDRMask(24, 32), Add4Options{Strict: true})
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Kind != ConflictNetwork {
+		Del4("lo0", net.ParseIP("198.51.100.0"), net.CIDRMask(24, 32))
+		t.Errorf("Strict Add4WithOptions() should refuse the network address, got: %v", err)
+	}
+}
+
+// TestConflictKindString tests the ConflictKind.String method
+func TestConflictKindString(t *testing.T) {
+	if s := ConflictOverlap.String(); s != "overlap" {
+		t.Errorf("String() = %q, expected %q", s, "overlap")
+	}
+	if s := ConflictRoute.String(); s != "static route" {
+		t.Errorf("String() = %q, expected %q", s, "static route")
+	}
+}
+
+// TestAdd6AddrZone tests adding a link-local address by zone
+func TestAdd6AddrZone(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	addr := netip.MustParseAddr("fe80::35%lo0")
+	if err := Add6Addr("", addr, 64); err != nil {
+		t.Fatalf("Add6Addr() failed: %v", err)
+	}
+	if err := Del6Addr("lo0", addr, 64); err != nil {
+		t.Errorf("Del6Addr() failed: %v", err)
+	}
+}
+
+// TestAdd6AddrZoneInvalid tests zone validation
+func TestAdd6AddrZoneInvalid(t *testing.T) {
+	if err := Add6Addr("", netip.MustParseAddr("fe80::35"), 64); !isyscall.IsValidation(err) {
+		t.Errorf("Add6Addr() without interface or zone should fail validation, got %v", err)
+	}
+	if err := Add6Addr("em999", netip.MustParseAddr("fe80::35%lo0"), 64); !isyscall.IsValidation(err) {
+		t.Errorf("Add6Addr() with mismatched zone should fail validation, got %v", err)
+	}
+	if err := Add6Addr("lo0", netip.MustParseAddr("192.0.2.1"), 24); !isyscall.IsValidation(err) {
+		t.Errorf("Add6Addr() with IPv4 address should fail validation, got %v", err)
+	}
+}
+
+// TestPolicies tests listing the address selection policy table
+func TestPolicies(t *testing.T) {
+	policies, err := Policies()
+	if err != nil {
+		t.Fatalf("Policies() failed: %v", err)
+	}
+	for _, p := range policies {
+		t.Logf("%s prec=%d label=%d use=%d", p.Prefix, p.Precedence, p.Label, p.Use)
+	}
+}
+
+// TestPolicyEntry tests prefix conversion for the policy table
+func TestPolicyEntry(t *testing.T) {
+	entry, err := policyEntry(netip.MustParsePrefix("10.0.0.0/8"))
+	if err != nil {
+		t.Fatalf("policyEntry() failed: %v", err)
+	}
+	if got := netip.AddrFrom16(entry.Addr); got != netip.MustParseAddr("::ffff:10.0.0.0") {
+		t.Errorf("IPv4 prefix mapped to %s", got)
+	}
+	if ones, _ := net.IPMask(entry.Mask[:]).Size(); ones != 104 {
+		t.Errorf("IPv4 /8 mapped to /%d, want /104", ones)
+	}
+
+	entry, _ = policyEntry(netip.MustParsePrefix("2001:db8::1/32"))
+	if got := netip.AddrFrom16(entry.Addr); got != netip.MustParseAddr("2001:db8::") {
+		t.Errorf("Host bits not cleared: %s", got)
+	}
+
+	if _, err := policyEntry(netip.Prefix{}); err == nil {
+		t.Error("policyEntry() should fail for invalid prefix")
+	}
+}
+
+// TestAddDelPolicy tests adding, replacing and deleting a policy entry
+func TestAddDelPolicy(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	prefix := netip.MustParsePrefix("fd00:db8:36::/48")
+	if err := AddPolicy(Policy{Prefix: prefix, Precedence: 45, Label: 14}); err != nil {
+		t.Fatalf("AddPolicy() failed: %v", err)
+	}
+	defer DelPolicy(prefix)
+
+	// Replace with a different precedence
+	if err := AddPolicy(Policy{Prefix: prefix, Precedence: 46, Label: 14}); err != nil {
+		t.Fatalf("AddPolicy() replace failed: %v", err)
+	}
+
+	policies, err := Policies()
+	if err != nil {
+		t.Fatalf("Policies() failed: %v", err)
+	}
+	found := false
+	for _, p := range policies {
+		if p.Prefix == prefix {
+			found = true
+			if p.Precedence != 46 {
+				t.Errorf("Expected precedence 46, got %d", p.Precedence)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Policy %s not listed", prefix)
+	}
+
+	if err := DelPolicy(prefix); err != nil {
+		t.Errorf("DelPolicy() failed: %v", err)
+	}
+	if err := DelPolicy(prefix); err != nil {
+		t.Errorf("DelPolicy() should be idempotent: %v", err)
+	}
+	if err := DelPolicy(netip.MustParsePrefix("fd00:db8:37::/48")); err != nil {
+		t.Errorf("DelPolicy() of a prefix never added should return nil: %v", err)
+	}
+}
+
+// TestAnnounceInvalid tests announcement argument validation
+func TestAnnounceInvalid(t *testing.T) {
+	addr := netip.MustParseAddr("192.0.2.10")
+
+	err := AnnounceWithOptions("lo0", addr, AnnounceOptions{Count: -1})
+	if !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for negative count, got %v", err)
+	}
+	err = AnnounceWithOptions("lo0", addr, AnnounceOptions{Interval: -time.Second})
+	if !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for negative interval, got %v", err)
+	}
+	for _, bad := range []netip.Addr{{}, netip.MustParseAddr("0.0.0.0"), netip.MustParseAddr("ff02::1")} {
+		if err := Announce("lo0", bad); !isyscall.IsValidation(err) {
+			t.Errorf("Expected validation error for %s, got %v", bad, err)
+		}
+	}
+
+	// lo0 has no Ethernet address
+	if err := Announce("lo0", netip.MustParseAddr("127.0.0.1")); !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error on lo0, got %v", err)
+	}
+}
+
+// TestAnnounce tests sending announcements on an epair
+func TestAnnounce(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+
+	if err := ifc.SetUp(pair.A, true); err != nil {
+		t.Fatalf("SetUp() failed: %v", err)
+	}
+
+	addr4 := netip.MustParseAddr("192.0.2.37")
+	err = Add4WithOptions(pair.A, addr4.AsSlice(), net.CIDRMask(24, 32), Add4Options{
+		Announce: &AnnounceOptions{Count: 2, Interval: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("Add4WithOptions() with Announce failed: %v", err)
+	}
+
+	addr6 := netip.MustParseAddr("2001:db8:37::1")
+	err = Add6WithOptions(pair.A, addr6.AsSlice(), 64, Add6Options{
+		Flags:    FlagNoDAD,
+		Announce: &AnnounceOptions{Count: 1},
+	})
+	if err != nil {
+		t.Fatalf("Add6WithOptions() with Announce failed: %v", err)
+	}
+
+	if err := Announce(pair.A, addr4); err != nil {
+		t.Errorf("Announce() IPv4 failed: %v", err)
+	}
+	if err := AnnounceWithOptions(pair.A, addr6, AnnounceOptions{Count: 1}); err != nil {
+		t.Errorf("AnnounceWithOptions() IPv6 failed: %v", err)
+	}
+}
+
+// TestForwarding tests reading and toggling forwarding
+func TestForwarding(t *testing.T) {
+	for _, family := range []Family{IPv4, IPv6} {
+		if _, err := Forwarding(family); err != nil {
+			t.Errorf("Forwarding(%s) failed: %v", family, err)
+		}
+	}
+	if err := SetForwarding(Family(5), true); !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for unknown family, got %v", err)
+	}
+
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	old, err := Forwarding(IPv6)
+	if err != nil {
+		t.Fatalf("Forwarding() failed: %v", err)
+	}
+	defer SetForwarding(IPv6, old)
+
+	if err := SetForwarding(IPv6, !old); err != nil {
+		t.Fatalf("SetForwarding() failed: %v", err)
+	}
+	if v, _ := Forwarding(IPv6); v == old {
+		t.Errorf("Forwarding still %v after SetForwarding(%v)", v, !old)
+	}
+}
@@ -0,0 +1,230 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// ConflictKind describes why Check rejected an address.
+type ConflictKind int
+
+const (
+	ConflictDuplicate ConflictKind = iota // Same address on another interface
+	ConflictOverlap                       // Subnet overlaps a subnet on another interface
+	ConflictNetwork                       // Address is the network (subnet-router anycast) address
+	ConflictBroadcast                     // Address is the IPv4 broadcast address
+	ConflictRoute                         // Subnet overlaps a static route
+)
+
+// String returns the conflict kind, e.g. "duplicate".
+func (k ConflictKind) String() string {
+	switch k {
+	case ConflictDuplicate:
+		return "duplicate"
+	case ConflictOverlap:
+		return "overlap"
+	case ConflictNetwork:
+		return "network address"
+	case ConflictBroadcast:
+		return "broadcast address"
+	case ConflictRoute:
+		return "static route"
+	}
+	return fmt.Sprintf("conflict(%d)", int(k))
+}
+
+// ValidationError describes one conflict found by Check.
+//
+// It unwraps to the validation error the other packages return for
+// invalid arguments, which unwraps to ErrInvalidArgument.
+type ValidationError struct {
+	Kind      ConflictKind
+	Address   netip.Prefix // Address being checked
+	Interface string       // Interface of the conflicting address or route
+	Existing  netip.Prefix // Conflicting address or route destination
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Address, e.conflict())
+}
+
+func (e *ValidationError) Unwrap() error {
+	return isyscall.NewValidationError("addr", e.Address.String(), e.conflict())
+}
+
+// conflict describes the conflict without the checked address
+func (e *ValidationError) conflict() string {
+	switch e.Kind {
+	case ConflictNetwork, ConflictBroadcast:
+		return fmt.Sprintf("is the %s of %s", e.Kind, e.Address.Masked())
+	case ConflictRoute:
+		return fmt.Sprintf("overlaps static route to %s via %s", e.Existing, e.Interface)
+	}
+	return fmt.Sprintf("%s of %s on %s", e.Kind, e.Existing, e.Interface)
+}
+
+// ValidationErrors is the list of conflicts returned by Check.
+//
+// Use errors.As to get the first *ValidationError, or a type assertion to
+// get all of them.
+type ValidationErrors []*ValidationError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return "address conflicts: " + strings.Join(msgs, "; ")
+}
+
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v))
+	for i, e := range v {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Check reports whether adding addr to iface would conflict with the
+// current configuration.
+//
+// addr is the address with its prefix length, e.g. 192.0.2.10/24. Check
+// reports the same address on another interface, subnets overlapping a
+// subnet on another interface, addresses that equal the network or
+// broadcast address, and subnets overlapping a static route. IPv6
+// link-local addresses are only unique per link and are not compared
+// across interfaces. Returns nil or ValidationErrors.
+//
+// Works without special privileges.
+//
+// Example:
+//
+//	err := ip.Check("em1", netip.MustParsePrefix("192.0.2.10/24"))
+//	var ve *ip.ValidationError
+//	if errors.As(err, &ve) && ve.Kind == ip.ConflictOverlap {
+//		log.Fatalf("%s already in use on %s", ve.Existing, ve.Interface)
+//	}
+func Check(iface string, addr netip.Prefix) error {
+	if !addr.IsValid() {
+		return isyscall.NewValidationError("addr", addr.String(), "invalid prefix")
+	}
+	addr = netip.PrefixFrom(addr.Addr().Unmap(), addr.Bits())
+
+	var errs ValidationErrors
+	conflict := func(kind ConflictKind, ifname string, existing netip.Prefix) {
+		errs = append(errs, &ValidationError{Kind: kind, Address: addr, Interface: ifname, Existing: existing})
+	}
+
+	a := addr.Addr()
+	network := addr.Masked().Addr()
+	hostBits := a.BitLen() - addr.Bits()
+	if a.Is4() && hostBits >= 2 {
+		if a == network {
+			conflict(ConflictNetwork, iface, addr.Masked())
+		}
+		if a == broadcast(addr) {
+			conflict(ConflictBroadcast, iface, addr.Masked())
+		}
+	}
+	if a.Is6() && hostBits >= 2 && a == network {
+		conflict(ConflictNetwork, iface, addr.Masked())
+	}
+
+	linkLocal := a.IsLinkLocalUnicast()
+
+	ifaces, err := ifops.List()
+	if err != nil {
+		return fmt.Errorf("check %s: %w", addr, err)
+	}
+	for _, ifc := range ifaces {
+		if ifc.Name == iface || linkLocal {
+			continue
+		}
+		for _, na := range ifc.Addrs {
+			existing, ok := ipNetPrefix(na)
+			if !ok || existing.Addr().Is4() != a.Is4() || existing.Addr().IsLinkLocalUnicast() {
+				continue
+			}
+			switch {
+			case existing.Addr() == a:
+				conflict(ConflictDuplicate, ifc.Name, existing)
+			case existing.Overlaps(addr):
+				conflict(ConflictOverlap, ifc.Name, existing)
+			}
+		}
+	}
+
+	if !linkLocal {
+		af := constants.AF_INET6
+		if a.Is4() {
+			af = constants.AF_INET
+		}
+		routes, err := routing.Dump(af, constants.NET_RT_DUMP, 0, -1)
+		if err != nil {
+			return fmt.Errorf("check %s: %w", addr, err)
+		}
+		for _, m := range routes {
+			if m.Flags&constants.RTF_STATIC == 0 || m.Flags&constants.RTF_GATEWAY == 0 {
+				continue
+			}
+			existing, ok := ipNetPrefix(routing.DstPrefix(m))
+			if !ok || existing.Bits() == 0 || existing.Addr().IsLinkLocalUnicast() {
+				continue // The default route overlaps everything
+			}
+			if existing.Overlaps(addr) {
+				name, _ := ifops.NameByIndex(m.Index)
+				conflict(ConflictRoute, name, existing)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// broadcast returns the IPv4 broadcast address of a prefix
+func broadcast(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().As4()
+	for i := p.Bits(); i < 32; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	return netip.AddrFrom4(b)
+}
+
+// ipNetPrefix converts an interface address or route destination
+func ipNetPrefix(a net.Addr) (netip.Prefix, bool) {
+	n, ok := a.(*net.IPNet)
+	if !ok || n == nil {
+		return netip.Prefix{}, false
+	}
+	ip, ok := netip.AddrFromSlice(n.IP)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+	ones, bits := n.Mask.Size()
+	if bits == 0 {
+		ones = ip.Unmap().BitLen() // Missing or non-contiguous mask
+	}
+	return netip.PrefixFrom(ip.Unmap(), ones), true
+}
+
+// checkStrict runs Check for the Strict option of the add functions
+func checkStrict(iface string, ip net.IP, ones int) error {
+	a, _ := netip.AddrFromSlice(ip)
+	if err := Check(iface, netip.PrefixFrom(a.Unmap(), ones)); err != nil {
+		return fmt.Errorf("add %s/%d to %s: %w", ip, ones, iface, err)
+	}
+	return nil
+}
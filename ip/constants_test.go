@@ -0,0 +1,33 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"testing"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+)
+
+// TestConstants checks the address flags against the system headers
+func TestConstants(t *testing.T) {
+	values := []struct {
+		name      string
+		got, want int
+	}{
+		{"IN6_IFF_ANYCAST", int(FlagAnycast), constants.IN6_IFF_ANYCAST},
+		{"IN6_IFF_TENTATIVE", int(FlagTentative), constants.IN6_IFF_TENTATIVE},
+		{"IN6_IFF_DUPLICATED", int(FlagDuplicated), constants.IN6_IFF_DUPLICATED},
+		{"IN6_IFF_DETACHED", int(FlagDetached), constants.IN6_IFF_DETACHED},
+		{"IN6_IFF_DEPRECATED", int(FlagDeprecated), constants.IN6_IFF_DEPRECATED},
+		{"IN6_IFF_NODAD", int(FlagNoDAD), constants.IN6_IFF_NODAD},
+		{"IN6_IFF_AUTOCONF", int(FlagAutoconf), constants.IN6_IFF_AUTOCONF},
+		{"IN6_IFF_TEMPORARY", int(FlagTemporary), constants.IN6_IFF_TEMPORARY},
+		{"IN6_IFF_PREFER_SOURCE", int(FlagPreferSource), constants.IN6_IFF_PREFER_SOURCE},
+	}
+	for _, v := range values {
+		if v.got != v.want {
+			t.Errorf("%s = %#x, want %#x", v.name, v.got, v.want)
+		}
+	}
+}
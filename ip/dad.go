@@ -0,0 +1,74 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/ipaddr"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// dadPollInterval is how often WaitDAD re-reads the address flags.
+const dadPollInterval = 100 * time.Millisecond
+
+// WaitDAD waits until Duplicate Address Detection for an IPv6 address has
+// finished and the address is usable.
+//
+// Returns nil once the address is no longer tentative, ErrDuplicateAddress if
+// another node on the link uses the address, or the context error if ctx is
+// done first. The duplicated address is left on the interface, as the kernel
+// does; remove it with Del6.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := ip.WaitDAD(ctx, "em0", addr); errors.Is(err, ip.ErrDuplicateAddress) {
+//		log.Fatalf("%s is already in use on the link", addr)
+//	}
+func WaitDAD(ctx context.Context, iface string, addr net.IP) error {
+	if addr.To16() == nil || addr.To4() != nil {
+		return isyscall.NewValidationError("addr", addr.String(), "not an IPv6 address")
+	}
+
+	ticker := time.NewTicker(dadPollInterval)
+	defer ticker.Stop()
+
+	for {
+		flags, err := ipaddr.Flags6(iface, addr)
+		if err != nil {
+			return fmt.Errorf("wait for DAD of %s on %s: %w", addr, iface, err)
+		}
+		if AddrFlags(flags)&FlagDuplicated != 0 {
+			return fmt.Errorf("wait for DAD of %s on %s: %w", addr, iface, ErrDuplicateAddress)
+		}
+		if AddrFlags(flags)&FlagTentative == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for DAD of %s on %s: %w", addr, iface, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flags6 returns the flags of an IPv6 address on an interface.
+//
+// Returns ErrNotFound if the address is not configured on the interface.
+func Flags6(iface string, addr net.IP) (AddrFlags, error) {
+	if addr.To16() == nil || addr.To4() != nil {
+		return 0, isyscall.NewValidationError("addr", addr.String(), "not an IPv6 address")
+	}
+	flags, err := ipaddr.Flags6(iface, addr)
+	if err != nil {
+		return 0, fmt.Errorf("get flags of %s on %s: %w", addr, iface, err)
+	}
+	return AddrFlags(flags), nil
+}
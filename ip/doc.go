@@ -32,9 +32,121 @@ IPv6 addresses:
 		log.Fatal(err)
 	}
 
+# Address Lifetimes and Flags
+
+Add6WithOptions sets the valid and preferred lifetimes and the address flags.
+This is useful to deprecate an address gracefully during renumbering or to
+mark anycast addresses:
+
+	// Deprecate the old address, remove it in two hours
+	err := ip.Add6WithOptions("em0", old, 64, ip.Add6Options{
+		ValidLifetime: 2 * time.Hour,
+		Flags:         ip.FlagDeprecated,
+	})
+
+	// Anycast service address without Duplicate Address Detection
+	err = ip.Add6WithOptions("em0", anycast, 64, ip.Add6Options{
+		Flags: ip.FlagAnycast | ip.FlagNoDAD,
+	})
+
+# Duplicate Address Detection
+
+A new IPv6 address is tentative until Duplicate Address Detection finishes,
+and binding to it fails until then. WaitDAD blocks until the address is
+usable, or returns ErrDuplicateAddress:
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ip.WaitDAD(ctx, "em0", ip6); err != nil {
+		log.Fatal(err)
+	}
+
+The same wait is available on add through Add6Options.WaitDAD:
+
+	err := ip.Add6WithOptions("em0", ip6, 64, ip.Add6Options{WaitDAD: 5 * time.Second})
+
+# Link-Local Addresses
+
+Add6Addr and Del6Addr take a netip.Addr, whose zone names the interface of
+a link-local address:
+
+	err := ip.Add6Addr("", netip.MustParseAddr("fe80::2%epair0b"), 64)
+
+# Address Generation
+
+Interface identifiers can be generated in pure Go: ModifiedEUI64 from a MAC
+address, StablePrivacyID for RFC 7217 stable-privacy identifiers,
+TemporaryID for RFC 4941 temporary identifiers, and Address and LinkLocal
+to combine them with a prefix. AddFromPrefix generates and adds an address
+in one step:
+
+	prefix := netip.MustParsePrefix("2001:db8:1:2::/64")
+
+	// Same address for the same prefix, interface and secret on every run
+	addr, err := ip.AddFromPrefix("epair5b", prefix, ip.StablePrivacy{Secret: secret})
+
+	// Classic SLAAC address from the interface's MAC
+	addr, err = ip.AddFromPrefix("em0", prefix, ip.EUI64{})
+
+# Conflict Detection
+
+The kernel accepts overlapping subnets on different interfaces, which
+silently breaks routing. Check reports such conflicts before an address is
+added: duplicates and overlapping subnets on other interfaces, network and
+broadcast addresses, and overlapping static routes. Each conflict is a
+*ValidationError:
+
+	err := ip.Check("em1", netip.MustParsePrefix("192.0.2.10/24"))
+	var ve *ip.ValidationError
+	if errors.As(err, &ve) {
+		log.Fatalf("%s conflicts: %s", ve.Address, ve)
+	}
+
+Add4Options.Strict and Add6Options.Strict run the same check on add.
+
+# Source Address Selection Policy
+
+The RFC 6724 policy table decides which source address is used when a
+host has several. Policies, AddPolicy, DelPolicy and FlushPolicies manage
+it like ip6addrctl(8):
+
+	// Prefer IPv4 over IPv6
+	err := ip.AddPolicy(ip.Policy{
+		Prefix:     netip.MustParsePrefix("::ffff:0:0/96"),
+		Precedence: 100,
+		Label:      4,
+	})
+
+# Announcements
+
+After an address moved between hosts or interfaces, switches and neighbors
+keep stale entries until they time out. Announce sends gratuitous ARP for
+IPv4 and unsolicited Neighbor Advertisements for IPv6 addresses:
+
+	err := ip.Announce("em0", netip.MustParseAddr("192.0.2.10"))
+
+Add4Options.Announce and Add6Options.Announce announce the address right
+after it was added.
+
+# Forwarding
+
+SetForwarding turns the host into a router for one address family:
+
+	err := ip.SetForwarding(ip.IPv6, true)
+
+# CARP Addresses
+
+Addresses bound to a CARP virtual host carry its vhid, set through
+Add4Options.Vhid or Add6Options.Vhid. The vhid must be configured first
+(see package carp):
+
+	err := ip.Add4WithOptions("em0", net.ParseIP("192.0.2.1"), net.CIDRMask(24, 32),
+		ip.Add4Options{Vhid: 1})
+
 # Permissions
 
-All operations require root privileges.
+All operations that change addresses or policies require root privileges.
+Check, Flags6, Policies, Forwarding and the address generation helpers do not.
 
 # Idempotency
 
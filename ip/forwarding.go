@@ -0,0 +1,63 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"fmt"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Forwarding reports whether packets of family are forwarded between
+// interfaces (net.inet.ip.forwarding, net.inet6.ip6.forwarding).
+func Forwarding(family Family) (bool, error) {
+	name, err := forwardingSysctl(family)
+	if err != nil {
+		return false, err
+	}
+	v, err := isyscall.SysctlInt(name)
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", name, err)
+	}
+	return v != 0, nil
+}
+
+// SetForwarding enables or disables forwarding of family packets between
+// interfaces, turning the host into a router.
+//
+// The setting is system-wide and not persistent. Requires root privileges.
+//
+// Example:
+//
+//	// sysctl net.inet.ip.forwarding=1 net.inet6.ip6.forwarding=1
+//	for _, family := range []ip.Family{ip.IPv4, ip.IPv6} {
+//		if err := ip.SetForwarding(family, true); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func SetForwarding(family Family, enable bool) error {
+	name, err := forwardingSysctl(family)
+	if err != nil {
+		return err
+	}
+	v := 0
+	if enable {
+		v = 1
+	}
+	if err := isyscall.SetSysctlInt(name, v); err != nil {
+		return fmt.Errorf("set %s=%d: %w", name, v, err)
+	}
+	return nil
+}
+
+func forwardingSysctl(family Family) (string, error) {
+	switch family {
+	case IPv4:
+		return constants.SysctlIPForwarding, nil
+	case IPv6:
+		return constants.SysctlIP6Forwarding, nil
+	}
+	return "", isyscall.NewValidationError("family", family.String(), "must be IPv4 or IPv6")
+}
@@ -0,0 +1,138 @@
+package ip
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+)
+
+// Ethernet frame layout used by the announcement builders.
+const (
+	etherHeaderLen = 14
+	etherMinLen    = 60 // Minimum frame length without the FCS
+
+	etherTypeARP  = 0x0806
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+
+	arpRequest = 1
+
+	ipv6HeaderLen          = 40
+	icmpv6NeighborAdvert   = 136
+	ndOptTargetLinkAddr    = 2
+	ndNAFlagRouter         = 0x80000000
+	ndNAFlagOverride       = 0x20000000
+	neighborAdvertLen      = 24 // ICMPv6 header, flags and target
+	neighborAdvertOptLen   = 8  // Target link-layer address option for Ethernet
+	protocolICMPv6         = 58
+	neighborDiscoveryHop   = 255
+	neighborAdvertFrameLen = etherHeaderLen + ipv6HeaderLen + neighborAdvertLen + neighborAdvertOptLen
+)
+
+var (
+	etherBroadcast = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	allNodesEther  = net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x01}
+	allNodesAddr   = netip.MustParseAddr("ff02::1")
+)
+
+// gratuitousARP builds a broadcast ARP request announcing that addr is at
+// mac. Sender and target protocol addresses are both addr, as recommended
+// by RFC 5227; the frame is padded to the Ethernet minimum.
+func gratuitousARP(mac net.HardwareAddr, addr netip.Addr) ([]byte, error) {
+	if len(mac) != 6 {
+		return nil, errors.New("gratuitous ARP needs a 6-byte Ethernet address")
+	}
+	if !addr.Unmap().Is4() {
+		return nil, errors.New("gratuitous ARP needs an IPv4 address")
+	}
+	ip4 := addr.Unmap().As4()
+
+	frame := make([]byte, etherMinLen)
+	putEtherHeader(frame, etherBroadcast, mac, etherTypeARP)
+
+	arp := frame[etherHeaderLen:]
+	binary.BigEndian.PutUint16(arp[0:], 1) // Hardware type Ethernet
+	binary.BigEndian.PutUint16(arp[2:], etherTypeIPv4)
+	arp[4] = 6 // Hardware address length
+	arp[5] = 4 // Protocol address length
+	binary.BigEndian.PutUint16(arp[6:], arpRequest)
+	copy(arp[8:], mac)     // Sender hardware address
+	copy(arp[14:], ip4[:]) // Sender protocol address
+	// Target hardware address stays zero
+	copy(arp[24:], ip4[:]) // Target protocol address
+	return frame, nil
+}
+
+// unsolicitedNA builds an unsolicited Neighbor Advertisement for addr to
+// the all-nodes multicast group (RFC 4861 section 7.2.6). The override flag
+// is set so neighbors replace cached link-layer addresses, and the
+// advertisement carries mac as target link-layer address.
+func unsolicitedNA(mac net.HardwareAddr, addr netip.Addr, router bool) ([]byte, error) {
+	if len(mac) != 6 {
+		return nil, errors.New("neighbor advertisement needs a 6-byte Ethernet address")
+	}
+	if !addr.Is6() || addr.Is4In6() {
+		return nil, errors.New("neighbor advertisement needs an IPv6 address")
+	}
+	src := addr.WithZone("").As16()
+	dst := allNodesAddr.As16()
+
+	frame := make([]byte, neighborAdvertFrameLen)
+	putEtherHeader(frame, allNodesEther, mac, etherTypeIPv6)
+
+	ip6 := frame[etherHeaderLen:]
+	ip6[0] = 6 << 4 // Version, traffic class and flow label zero
+	binary.BigEndian.PutUint16(ip6[4:], neighborAdvertLen+neighborAdvertOptLen)
+	ip6[6] = protocolICMPv6
+	ip6[7] = neighborDiscoveryHop
+	copy(ip6[8:], src[:])
+	copy(ip6[24:], dst[:])
+
+	na := ip6[ipv6HeaderLen:]
+	na[0] = icmpv6NeighborAdvert
+	flags := uint32(ndNAFlagOverride)
+	if router {
+		flags |= ndNAFlagRouter
+	}
+	binary.BigEndian.PutUint32(na[4:], flags)
+	copy(na[8:], src[:]) // Target address
+	na[24] = ndOptTargetLinkAddr
+	na[25] = 1 // Option length in units of 8 bytes
+	copy(na[26:], mac)
+
+	binary.BigEndian.PutUint16(na[2:], icmpv6Checksum(src, dst, na))
+	return frame, nil
+}
+
+func putEtherHeader(frame []byte, dst, src net.HardwareAddr, etherType uint16) {
+	copy(frame[0:], dst)
+	copy(frame[6:], src)
+	binary.BigEndian.PutUint16(frame[12:], etherType)
+}
+
+// icmpv6Checksum computes the ICMPv6 checksum over the IPv6 pseudo-header
+// and msg, whose checksum field must be zero.
+func icmpv6Checksum(src, dst [16]byte, msg []byte) uint16 {
+	var sum uint32
+	add := func(b []byte) {
+		for i := 0; i+1 < len(b); i += 2 {
+			sum += uint32(binary.BigEndian.Uint16(b[i:]))
+		}
+		if len(b)%2 == 1 {
+			sum += uint32(b[len(b)-1]) << 8
+		}
+	}
+	add(src[:])
+	add(dst[:])
+	var pseudo [8]byte
+	binary.BigEndian.PutUint32(pseudo[0:], uint32(len(msg)))
+	pseudo[7] = protocolICMPv6
+	add(pseudo[:])
+	add(msg)
+
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,105 @@
+package ip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+var testMAC = net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+
+// TestGratuitousARP tests the gratuitous ARP frame layout
+func TestGratuitousARP(t *testing.T) {
+	frame, err := gratuitousARP(testMAC, netip.MustParseAddr("192.0.2.10"))
+	if err != nil {
+		t.Fatalf("gratuitousARP() failed: %v", err)
+	}
+
+	want, _ := hex.DecodeString(
+		"ffffffffffff" + "001122334455" + "0806" + // Ethernet
+			"0001" + "0800" + "06" + "04" + "0001" + // ARP request
+			"001122334455" + "c000020a" + // Sender
+			"000000000000" + "c000020a") // Target
+	if len(frame) != etherMinLen {
+		t.Errorf("Frame length %d, want %d", len(frame), etherMinLen)
+	}
+	if !bytes.Equal(frame[:len(want)], want) {
+		t.Errorf("Frame mismatch:\n got %x\nwant %x", frame[:len(want)], want)
+	}
+	for _, b := range frame[len(want):] {
+		if b != 0 {
+			t.Errorf("Padding not zero: %x", frame[len(want):])
+			break
+		}
+	}
+
+	// IPv4-mapped addresses are announced as IPv4
+	mapped, err := gratuitousARP(testMAC, netip.MustParseAddr("::ffff:192.0.2.10"))
+	if err != nil || !bytes.Equal(mapped, frame) {
+		t.Errorf("gratuitousARP() with IPv4-mapped address = %x, %v", mapped, err)
+	}
+
+	if _, err := gratuitousARP(testMAC, netip.MustParseAddr("2001:db8::1")); err == nil {
+		t.Error("gratuitousARP() should fail for IPv6 address")
+	}
+	if _, err := gratuitousARP(net.HardwareAddr{1, 2, 3}, netip.MustParseAddr("192.0.2.10")); err == nil {
+		t.Error("gratuitousARP() should fail for short MAC")
+	}
+}
+
+// TestUnsolicitedNA tests the unsolicited Neighbor Advertisement frame layout
+func TestUnsolicitedNA(t *testing.T) {
+	addr := netip.MustParseAddr("2001:db8::1")
+	frame, err := unsolicitedNA(testMAC, addr, false)
+	if err != nil {
+		t.Fatalf("unsolicitedNA() failed: %v", err)
+	}
+	if len(frame) != neighborAdvertFrameLen {
+		t.Fatalf("Frame length %d, want %d", len(frame), neighborAdvertFrameLen)
+	}
+
+	want, _ := hex.DecodeString(
+		"333300000001" + "001122334455" + "86dd" + // Ethernet
+			"60000000" + "0020" + "3a" + "ff" + // IPv6 header
+			"20010db8000000000000000000000001" + // Source
+			"ff020000000000000000000000000001" + // Destination
+			"88" + "00" + "9492" + "20000000" + // ICMPv6, override flag
+			"20010db8000000000000000000000001" + // Target
+			"0201" + "001122334455") // Target link-layer address
+	if !bytes.Equal(frame, want) {
+		t.Errorf("Frame mismatch:\n got %x\nwant %x", frame, want)
+	}
+
+	// The checksum over a valid message verifies to zero
+	src, dst := addr.As16(), allNodesAddr.As16()
+	if sum := icmpv6Checksum(src, dst, frame[etherHeaderLen+ipv6HeaderLen:]); sum != 0 {
+		t.Errorf("Checksum does not verify: %#04x", sum)
+	}
+
+	// Router flag, zone stripped
+	frame, err = unsolicitedNA(testMAC, netip.MustParseAddr("fe80::1%em0"), true)
+	if err != nil {
+		t.Fatalf("unsolicitedNA() failed: %v", err)
+	}
+	na := frame[etherHeaderLen+ipv6HeaderLen:]
+	if flags := binary.BigEndian.Uint32(na[4:]); flags != ndNAFlagRouter|ndNAFlagOverride {
+		t.Errorf("Flags %#08x, want router and override", flags)
+	}
+	src = netip.MustParseAddr("fe80::1").As16()
+	if sum := icmpv6Checksum(src, dst, na); sum != 0 {
+		t.Errorf("Checksum does not verify: %#04x", sum)
+	}
+
+	if _, err := unsolicitedNA(testMAC, netip.MustParseAddr("192.0.2.10"), false); err == nil {
+		t.Error("unsolicitedNA() should fail for IPv4 address")
+	}
+	if _, err := unsolicitedNA(testMAC, netip.MustParseAddr("::ffff:192.0.2.10"), false); err == nil {
+		t.Error("unsolicitedNA() should fail for IPv4-mapped address")
+	}
+	if _, err := unsolicitedNA(nil, addr, false); err == nil {
+		t.Error("unsolicitedNA() should fail without MAC")
+	}
+}
@@ -0,0 +1,188 @@
+package ip
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// InterfaceID is the 64-bit interface identifier of an IPv6 address.
+type InterfaceID [8]byte
+
+// String returns the identifier in IPv6 notation, e.g. "0211:22ff:fe33:4455".
+func (id InterfaceID) String() string {
+	return fmt.Sprintf("%04x:%04x:%04x:%04x",
+		binary.BigEndian.Uint16(id[0:]), binary.BigEndian.Uint16(id[2:]),
+		binary.BigEndian.Uint16(id[4:]), binary.BigEndian.Uint16(id[6:]))
+}
+
+// Reserved reports whether the identifier must not be used (RFC 5453): the
+// subnet-router anycast identifier, the IANA Ethernet block and the
+// reserved subnet anycast identifiers.
+func (id InterfaceID) Reserved() bool {
+	v := binary.BigEndian.Uint64(id[:])
+	switch {
+	case v == 0:
+		return true
+	case v>>24 == 0x02005efffe:
+		return true
+	case v >= 0xfdffffffffffff80:
+		return true
+	}
+	return false
+}
+
+// ModifiedEUI64 derives the modified EUI-64 interface identifier of a
+// 48-bit or 64-bit MAC address (RFC 4291 appendix A).
+//
+// Example:
+//
+//	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+//	id, _ := ip.ModifiedEUI64(mac) // 0211:22ff:fe33:4455
+func ModifiedEUI64(mac net.HardwareAddr) (InterfaceID, error) {
+	var id InterfaceID
+	switch len(mac) {
+	case 6:
+		copy(id[0:3], mac[0:3])
+		id[3], id[4] = 0xff, 0xfe
+		copy(id[5:8], mac[3:6])
+	case 8:
+		copy(id[:], mac)
+	default:
+		return InterfaceID{}, fmt.Errorf("invalid MAC address %q for EUI-64", mac)
+	}
+	id[0] ^= 0x02 // Invert the universal/local bit
+	return id, nil
+}
+
+// StablePrivacyID computes a semantically opaque interface identifier
+// (RFC 7217).
+//
+// The identifier is the low 64 bits of
+//
+//	SHA-256(prefix[0:8] || iface || networkID || dadCounter || secret)
+//
+// with dadCounter as a single byte. The same inputs always give the same
+// identifier, so addresses are stable across reboots but differ between
+// prefixes. If the result is a reserved identifier, dadCounter is
+// incremented and the hash recomputed, as after a DAD failure.
+//
+// The secret must be at least 16 bytes and should be kept per host.
+func StablePrivacyID(prefix netip.Prefix, iface string, networkID []byte, dadCounter int, secret []byte) (InterfaceID, error) {
+	if err := checkPrefix(prefix); err != nil {
+		return InterfaceID{}, err
+	}
+	if len(secret) < 16 {
+		return InterfaceID{}, errors.New("stable privacy secret must be at least 16 bytes")
+	}
+	if dadCounter < 0 || dadCounter > 255 {
+		return InterfaceID{}, fmt.Errorf("DAD counter %d out of range 0-255", dadCounter)
+	}
+
+	p := prefix.Masked().Addr().As16()
+	for ; dadCounter <= 255; dadCounter++ {
+		h := sha256.New()
+		h.Write(p[:8])
+		h.Write([]byte(iface))
+		h.Write(networkID)
+		h.Write([]byte{byte(dadCounter)})
+		h.Write(secret)
+		sum := h.Sum(nil)
+
+		var id InterfaceID
+		copy(id[:], sum[len(sum)-8:])
+		if !id.Reserved() {
+			return id, nil
+		}
+	}
+	return InterfaceID{}, errors.New("no unreserved stable privacy identifier")
+}
+
+// TemporaryID returns a random interface identifier for a temporary
+// address (RFC 4941).
+//
+// The universal/local bit is cleared and reserved identifiers are skipped.
+func TemporaryID() (InterfaceID, error) {
+	for {
+		var id InterfaceID
+		if _, err := rand.Read(id[:]); err != nil {
+			return InterfaceID{}, err
+		}
+		id[0] &^= 0x02
+		if !id.Reserved() {
+			return id, nil
+		}
+	}
+}
+
+// Address combines a prefix of at most 64 bits with an interface identifier.
+func Address(prefix netip.Prefix, id InterfaceID) (netip.Addr, error) {
+	if err := checkPrefix(prefix); err != nil {
+		return netip.Addr{}, err
+	}
+	a := prefix.Masked().Addr().As16()
+	copy(a[8:], id[:])
+	return netip.AddrFrom16(a), nil
+}
+
+// LinkLocal returns the fe80::/64 link-local address for an identifier.
+func LinkLocal(id InterfaceID) netip.Addr {
+	a := [16]byte{0: 0xfe, 1: 0x80}
+	copy(a[8:], id[:])
+	return netip.AddrFrom16(a)
+}
+
+func checkPrefix(prefix netip.Prefix) error {
+	if !prefix.IsValid() || !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+		return fmt.Errorf("invalid IPv6 prefix %s", prefix)
+	}
+	if prefix.Bits() > 64 {
+		return fmt.Errorf("prefix %s is longer than 64 bits", prefix)
+	}
+	return nil
+}
+
+// IIDInput is what a Method may use to compute an interface identifier.
+type IIDInput struct {
+	Prefix    netip.Prefix     // Prefix the address is generated in
+	Interface string           // Interface name
+	MAC       net.HardwareAddr // Link-layer address of the interface
+}
+
+// Method generates interface identifiers for AddFromPrefix.
+type Method interface {
+	InterfaceID(in IIDInput) (InterfaceID, error)
+}
+
+// EUI64 derives the identifier from the interface's MAC address.
+type EUI64 struct{}
+
+// InterfaceID implements Method.
+func (EUI64) InterfaceID(in IIDInput) (InterfaceID, error) {
+	return ModifiedEUI64(in.MAC)
+}
+
+// StablePrivacy derives a stable, opaque identifier from the prefix, the
+// interface name and a secret (RFC 7217). See StablePrivacyID.
+type StablePrivacy struct {
+	Secret     []byte // At least 16 bytes, kept per host
+	NetworkID  []byte // Optional network identifier, e.g. an SSID
+	DADCounter int    // Incremented after a DAD failure
+}
+
+// InterfaceID implements Method.
+func (m StablePrivacy) InterfaceID(in IIDInput) (InterfaceID, error) {
+	return StablePrivacyID(in.Prefix, in.Interface, m.NetworkID, m.DADCounter, m.Secret)
+}
+
+// Temporary generates a random identifier (RFC 4941).
+type Temporary struct{}
+
+// InterfaceID implements Method.
+func (Temporary) InterfaceID(IIDInput) (InterfaceID, error) {
+	return TemporaryID()
+}
@@ -0,0 +1,201 @@
+package ip
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+// TestModifiedEUI64 tests EUI-64 derivation from MAC addresses
+func TestModifiedEUI64(t *testing.T) {
+	tests := []struct {
+		mac  string
+		want string
+	}{
+		{"00:11:22:33:44:55", "0211:22ff:fe33:4455"},
+		{"02:00:00:00:00:01", "0000:00ff:fe00:0001"},
+		{"58:9c:fc:12:34:56", "5a9c:fcff:fe12:3456"},
+		{"00:11:22:33:44:55:66:77", "0211:2233:4455:6677"},
+	}
+
+	for _, tt := range tests {
+		mac, err := net.ParseMAC(tt.mac)
+		if err != nil {
+			t.Fatalf("ParseMAC(%s) failed: %v", tt.mac, err)
+		}
+		id, err := ModifiedEUI64(mac)
+		if err != nil {
+			t.Errorf("ModifiedEUI64(%s) failed: %v", tt.mac, err)
+			continue
+		}
+		if id.String() != tt.want {
+			t.Errorf("ModifiedEUI64(%s) = %s, want %s", tt.mac, id, tt.want)
+		}
+	}
+
+	if _, err := ModifiedEUI64(net.HardwareAddr{1, 2, 3}); err == nil {
+		t.Error("ModifiedEUI64() should fail for short MAC")
+	}
+}
+
+// TestStablePrivacyID tests RFC 7217 identifiers against fixed values
+func TestStablePrivacyID(t *testing.T) {
+	prefix := netip.MustParsePrefix("2001:db8:1:2::/64")
+	secret := []byte("0123456789abcdef")
+
+	id, err := StablePrivacyID(prefix, "em0", nil, 0, secret)
+	if err != nil {
+		t.Fatalf("StablePrivacyID() failed: %v", err)
+	}
+	if id.String() != "8bc9:f00e:68c8:f23f" {
+		t.Errorf("StablePrivacyID() = %s, want 8bc9:f00e:68c8:f23f", id)
+	}
+
+	// Reproducible
+	again, _ := StablePrivacyID(prefix, "em0", nil, 0, secret)
+	if again != id {
+		t.Errorf("StablePrivacyID() not reproducible: %s != %s", again, id)
+	}
+
+	// Host bits of the prefix do not matter
+	masked, _ := StablePrivacyID(netip.MustParsePrefix("2001:db8:1:2::1/64"), "em0", nil, 0, secret)
+	if masked != id {
+		t.Errorf("StablePrivacyID() depends on host bits: %s != %s", masked, id)
+	}
+
+	next, _ := StablePrivacyID(prefix, "em0", nil, 1, secret)
+	if next.String() != "85f8:c7ea:0a12:020c" {
+		t.Errorf("StablePrivacyID() with DAD counter 1 = %s, want 85f8:c7ea:0a12:020c", next)
+	}
+
+	other := []struct {
+		name   string
+		prefix netip.Prefix
+		iface  string
+		netID  []byte
+	}{
+		{"prefix", netip.MustParsePrefix("2001:db8:1:3::/64"), "em0", nil},
+		{"interface", prefix, "em1", nil},
+		{"network ID", prefix, "em0", []byte("ssid")},
+	}
+	for _, o := range other {
+		got, err := StablePrivacyID(o.prefix, o.iface, o.netID, 0, secret)
+		if err != nil {
+			t.Errorf("StablePrivacyID() with other %s failed: %v", o.name, err)
+		}
+		if got == id {
+			t.Errorf("StablePrivacyID() ignores the %s", o.name)
+		}
+	}
+}
+
+// TestStablePrivacyIDInvalid tests argument validation
+func TestStablePrivacyIDInvalid(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+
+	if _, err := StablePrivacyID(netip.MustParsePrefix("2001:db8::/64"), "em0", nil, 0, []byte("short")); err == nil {
+		t.Error("StablePrivacyID() should fail for short secret")
+	}
+	if _, err := StablePrivacyID(netip.MustParsePrefix("2001:db8::/96"), "em0", nil, 0, secret); err == nil {
+		t.Error("StablePrivacyID() should fail for prefix longer than 64 bits")
+	}
+	if _, err := StablePrivacyID(netip.MustParsePrefix("10.0.0.0/8"), "em0", nil, 0, secret); err == nil {
+		t.Error("StablePrivacyID() should fail for IPv4 prefix")
+	}
+	if _, err := StablePrivacyID(netip.MustParsePrefix("2001:db8::/64"), "em0", nil, 256, secret); err == nil {
+		t.Error("StablePrivacyID() should fail for DAD counter out of range")
+	}
+}
+
+// TestTemporaryID tests random identifiers
+func TestTemporaryID(t *testing.T) {
+	seen := make(map[InterfaceID]bool)
+	for i := 0; i < 100; i++ {
+		id, err := TemporaryID()
+		if err != nil {
+			t.Fatalf("TemporaryID() failed: %v", err)
+		}
+		if id[0]&0x02 != 0 {
+			t.Errorf("TemporaryID() %s has universal/local bit set", id)
+		}
+		if id.Reserved() {
+			t.Errorf("TemporaryID() returned reserved %s", id)
+		}
+		if seen[id] {
+			t.Errorf("TemporaryID() repeated %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+// TestReserved tests RFC 5453 reserved identifiers
+func TestReserved(t *testing.T) {
+	tests := []struct {
+		id   InterfaceID
+		want bool
+	}{
+		{InterfaceID{}, true},
+		{InterfaceID{0x02, 0x00, 0x5e, 0xff, 0xfe, 0x00, 0x52, 0x13}, true},
+		{InterfaceID{0x02, 0x00, 0x5e, 0xff, 0xfe, 0xff, 0xff, 0xff}, true},
+		{InterfaceID{0xfd, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x80}, true},
+		{InterfaceID{0xfd, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, false},
+		{InterfaceID{0x02, 0x00, 0x5e, 0xff, 0xfd, 0x00, 0x00, 0x00}, false},
+		{InterfaceID{0, 0, 0, 0, 0, 0, 0, 1}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.id.Reserved(); got != tt.want {
+			t.Errorf("%s.Reserved() = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+// TestAddress tests combining prefixes and identifiers
+func TestAddress(t *testing.T) {
+	id := InterfaceID{0x02, 0x11, 0x22, 0xff, 0xfe, 0x33, 0x44, 0x55}
+
+	addr, err := Address(netip.MustParsePrefix("2001:db8:1:2::/64"), id)
+	if err != nil {
+		t.Fatalf("Address() failed: %v", err)
+	}
+	if want := netip.MustParseAddr("2001:db8:1:2:211:22ff:fe33:4455"); addr != want {
+		t.Errorf("Address() = %s, want %s", addr, want)
+	}
+
+	addr, err = Address(netip.MustParsePrefix("2001:db8:ffff::/48"), id)
+	if err != nil {
+		t.Fatalf("Address() /48 failed: %v", err)
+	}
+	if want := netip.MustParseAddr("2001:db8:ffff:0:211:22ff:fe33:4455"); addr != want {
+		t.Errorf("Address() /48 = %s, want %s", addr, want)
+	}
+
+	if got, want := LinkLocal(id), netip.MustParseAddr("fe80::211:22ff:fe33:4455"); got != want {
+		t.Errorf("LinkLocal() = %s, want %s", got, want)
+	}
+}
+
+// TestMethods tests the Method implementations
+func TestMethods(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	in := IIDInput{Prefix: netip.MustParsePrefix("2001:db8::/64"), Interface: "em0", MAC: mac}
+
+	id, err := EUI64{}.InterfaceID(in)
+	if err != nil || id.String() != "0211:22ff:fe33:4455" {
+		t.Errorf("EUI64.InterfaceID() = %s, %v", id, err)
+	}
+
+	sp := StablePrivacy{Secret: []byte("0123456789abcdef")}
+	a, err := sp.InterfaceID(in)
+	if err != nil {
+		t.Fatalf("StablePrivacy.InterfaceID() failed: %v", err)
+	}
+	b, _ := StablePrivacyID(in.Prefix, in.Interface, nil, 0, sp.Secret)
+	if a != b {
+		t.Errorf("StablePrivacy.InterfaceID() = %s, want %s", a, b)
+	}
+
+	if _, err := (Temporary{}).InterfaceID(in); err != nil {
+		t.Errorf("Temporary.InterfaceID() failed: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/ipaddr"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Policy is an entry of the RFC 6724 source address selection policy
+// table.
+//
+// IPv4 addresses are matched as IPv4-mapped addresses, so IPv4 prefixes
+// are stored under ::ffff:0:0/96.
+type Policy struct {
+	Prefix     netip.Prefix // Addresses the entry applies to
+	Precedence int          // Higher is preferred
+	Label      int          // Source and destination should have equal labels
+	Use        uint64       // Times the entry matched (read-only)
+}
+
+// Policies returns the address selection policy table.
+//
+// This is the "ip6addrctl show" output. Works without special privileges.
+//
+// Example:
+//
+//	policies, err := ip.Policies()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, p := range policies {
+//		fmt.Printf("%-30s %4d %4d\n", p.Prefix, p.Precedence, p.Label)
+//	}
+func Policies() ([]Policy, error) {
+	entries, err := ipaddr.ListPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("list address selection policies: %w", err)
+	}
+
+	policies := make([]Policy, 0, len(entries))
+	for _, e := range entries {
+		ones, _ := net.IPMask(e.Mask[:]).Size()
+		policies = append(policies, Policy{
+			Prefix:     netip.PrefixFrom(netip.AddrFrom16(e.Addr), ones),
+			Precedence: e.Precedence,
+			Label:      e.Label,
+			Use:        e.Use,
+		})
+	}
+	return policies, nil
+}
+
+// AddPolicy adds an entry to the address selection policy table.
+//
+// An IPv4 prefix is converted to its IPv4-mapped form. An existing entry
+// for the same prefix is replaced. This is "ip6addrctl add".
+// Requires root privileges.
+//
+// Example:
+//
+//	// Prefer IPv4 over IPv6
+//	err := ip.AddPolicy(ip.Policy{
+//		Prefix:     netip.MustParsePrefix("::ffff:0:0/96"),
+//		Precedence: 100,
+//		Label:      4,
+//	})
+func AddPolicy(p Policy) error {
+	entry, err := policyEntry(p.Prefix)
+	if err != nil {
+		return err
+	}
+	if p.Precedence < 0 || p.Label < 0 {
+		return isyscall.NewValidationError("policy", fmt.Sprintf("%d/%d", p.Precedence, p.Label), "precedence and label must not be negative")
+	}
+	entry.Precedence = p.Precedence
+	entry.Label = p.Label
+
+	err = ipaddr.AddPolicy(entry)
+	if errors.Is(err, isyscall.ErrExists) {
+		if err = ipaddr.DelPolicy(entry); err == nil {
+			err = ipaddr.AddPolicy(entry)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("add address selection policy %s: %w", p.Prefix, err)
+	}
+	return nil
+}
+
+// DelPolicy deletes the entry for prefix from the address selection
+// policy table.
+//
+// This operation is idempotent - returns nil if the entry doesn't exist.
+// Requires root privileges.
+func DelPolicy(prefix netip.Prefix) error {
+	entry, err := policyEntry(prefix)
+	if err != nil {
+		return err
+	}
+
+	err = ipaddr.DelPolicy(entry)
+	if err != nil && !errors.Is(err, isyscall.ErrNotFound) {
+		return fmt.Errorf("delete address selection policy %s: %w", prefix, err)
+	}
+	return nil
+}
+
+// FlushPolicies deletes all entries of the address selection policy table,
+// leaving the kernel's built-in RFC 6724 defaults in effect.
+//
+// This is "ip6addrctl flush". Requires root privileges.
+func FlushPolicies() error {
+	policies, err := Policies()
+	if err != nil {
+		return err
+	}
+	for _, p := range policies {
+		if err := DelPolicy(p.Prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policyEntry converts a prefix to the kernel's IPv6 address and mask
+func policyEntry(prefix netip.Prefix) (ipaddr.Policy, error) {
+	if !prefix.IsValid() {
+		return ipaddr.Policy{}, isyscall.NewValidationError("prefix", prefix.String(), "invalid prefix")
+	}
+
+	addr, bits := prefix.Addr(), prefix.Bits()
+	if addr.Is4() {
+		addr, bits = netip.AddrFrom16(addr.As16()), bits+96
+	}
+
+	var entry ipaddr.Policy
+	entry.Addr = netip.PrefixFrom(addr, bits).Masked().Addr().As16()
+	copy(entry.Mask[:], net.CIDRMask(bits, 128))
+	return entry, nil
+}
@@ -0,0 +1,119 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// AddrFlags represents IPv6 address flags (IN6_IFF_*).
+type AddrFlags uint32
+
+const (
+	FlagAnycast      AddrFlags = 0x01  // Anycast address
+	FlagTentative    AddrFlags = 0x02  // Duplicate Address Detection in progress
+	FlagDuplicated   AddrFlags = 0x04  // Duplicate Address Detection failed
+	FlagDetached     AddrFlags = 0x08  // Address may be invalid (link moved)
+	FlagDeprecated   AddrFlags = 0x10  // Preferred lifetime expired
+	FlagNoDAD        AddrFlags = 0x20  // Skip Duplicate Address Detection
+	FlagAutoconf     AddrFlags = 0x40  // Configured by stateless autoconfiguration
+	FlagTemporary    AddrFlags = 0x80  // Temporary (privacy) address
+	FlagPreferSource AddrFlags = 0x100 // Prefer as source address
+)
+
+var addrFlagNames = []struct {
+	flag AddrFlags
+	name string
+}{
+	{FlagAnycast, "anycast"},
+	{FlagTentative, "tentative"},
+	{FlagDuplicated, "duplicated"},
+	{FlagDetached, "detached"},
+	{FlagDeprecated, "deprecated"},
+	{FlagNoDAD, "no_dad"},
+	{FlagAutoconf, "autoconf"},
+	{FlagTemporary, "temporary"},
+	{FlagPreferSource, "prefer_source"},
+}
+
+// String returns the flag names as printed by ifconfig(8), e.g. "anycast,no_dad".
+func (f AddrFlags) String() string {
+	var names []string
+	for _, fn := range addrFlagNames {
+		if f&fn.flag != 0 {
+			names = append(names, fn.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Family is an IP address family.
+type Family int
+
+const (
+	IPv4 Family = 4
+	IPv6 Family = 6
+)
+
+// String returns "IPv4" or "IPv6".
+func (f Family) String() string {
+	switch f {
+	case IPv4:
+		return "IPv4"
+	case IPv6:
+		return "IPv6"
+	}
+	return fmt.Sprintf("family%d", int(f))
+}
+
+// Add4Options configures an IPv4 address added with Add4WithOptions.
+type Add4Options struct {
+	Vhid int // CARP virtual host ID the address belongs to, 0 for none
+
+	// Strict makes Add4WithOptions run Check first and refuse conflicting
+	// addresses with ValidationErrors.
+	Strict bool
+
+	// Announce, if non-nil, makes Add4WithOptions send gratuitous ARP for
+	// the new address (see AnnounceWithOptions). The address stays
+	// configured if the announcement fails.
+	Announce *AnnounceOptions
+}
+
+// Add6Options configures an IPv6 address added with Add6WithOptions.
+//
+// A zero ValidLifetime means infinite, a zero PreferredLifetime means as
+// long as the address is valid. FlagDeprecated makes the kernel set the
+// preferred lifetime to zero, regardless of PreferredLifetime.
+type Add6Options struct {
+	ValidLifetime     time.Duration // How long the address stays valid
+	PreferredLifetime time.Duration // How long the address is preferred for new connections
+	Flags             AddrFlags     // FlagAnycast, FlagPreferSource, FlagNoDAD, FlagDeprecated, FlagAutoconf
+
+	// WaitDAD, if non-zero, makes Add6WithOptions wait up to this long for
+	// Duplicate Address Detection to finish (see WaitDAD).
+	WaitDAD time.Duration
+
+	Vhid int // CARP virtual host ID the address belongs to, 0 for none
+
+	// Strict makes Add6WithOptions run Check first and refuse conflicting
+	// addresses with ValidationErrors.
+	Strict bool
+
+	// Announce, if non-nil, makes Add6WithOptions send unsolicited Neighbor
+	// Advertisements for the new address (see AnnounceWithOptions). Set
+	// WaitDAD or FlagNoDAD as well, a tentative address must not be
+	// announced. The address stays configured if the announcement fails.
+	Announce *AnnounceOptions
+}
+
+// Re-export common errors from internal package
+var (
+	ErrNotFound         = syscall.ErrNotFound
+	ErrDuplicateAddress = syscall.ErrDuplicateAddress
+)
@@ -0,0 +1,71 @@
+//go:build freebsd
+// +build freebsd
+
+package ip
+
+import (
+	"net/netip"
+	"strconv"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Add6Addr adds an IPv6 address given as a netip.Addr, which may carry a
+// zone (fe80::2%em0).
+//
+// The zone, an interface name or index, must name iface. If iface is
+// empty, the zone selects the interface.
+//
+// Example:
+//
+//	err := ip.Add6Addr("", netip.MustParseAddr("fe80::2%epair0b"), 64)
+func Add6Addr(iface string, addr netip.Addr, prefixLen int) error {
+	return Add6AddrWithOptions(iface, addr, prefixLen, Add6Options{})
+}
+
+// Add6AddrWithOptions is Add6Addr with the options of Add6WithOptions.
+func Add6AddrWithOptions(iface string, addr netip.Addr, prefixLen int, opts Add6Options) error {
+	iface, err := zoneInterface(iface, addr)
+	if err != nil {
+		return err
+	}
+	return Add6WithOptions(iface, addr.WithZone("").AsSlice(), prefixLen, opts)
+}
+
+// Del6Addr removes an IPv6 address given as a netip.Addr, which may carry
+// a zone. See Add6Addr.
+func Del6Addr(iface string, addr netip.Addr, prefixLen int) error {
+	iface, err := zoneInterface(iface, addr)
+	if err != nil {
+		return err
+	}
+	return Del6(iface, addr.WithZone("").AsSlice(), prefixLen)
+}
+
+// zoneInterface checks the zone of addr against iface and returns the
+// interface to use
+func zoneInterface(iface string, addr netip.Addr) (string, error) {
+	if !addr.Is6() || addr.Is4In6() {
+		return "", isyscall.NewValidationError("addr", addr.String(), "not an IPv6 address")
+	}
+
+	zone := addr.Zone()
+	if zone == "" {
+		if iface == "" {
+			return "", isyscall.NewValidationError("iface", "", "no interface or zone given")
+		}
+		return iface, nil
+	}
+
+	name := zone
+	if idx, err := strconv.Atoi(zone); err == nil {
+		if name, err = ifops.NameByIndex(idx); err != nil {
+			return "", isyscall.NewValidationError("addr", addr.String(), "unknown zone")
+		}
+	}
+	if iface != "" && iface != name {
+		return "", isyscall.NewValidationError("addr", addr.String(), "zone does not match interface "+iface)
+	}
+	return name, nil
+}
@@ -0,0 +1,108 @@
+//go:build freebsd
+// +build freebsd
+
+package nd6
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/nd6ops"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Get returns the Neighbor Discovery settings of an interface.
+//
+// Example:
+//
+//	info, err := nd6.Get("em0")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("flags=<%s> linkmtu=%d chlim=%d\n", info.Flags, info.LinkMTU, info.CurHopLimit)
+func Get(iface string) (Info, error) {
+	ndi, err := nd6ops.Get(iface)
+	if err != nil {
+		return Info{}, fmt.Errorf("get nd6 info of %s: %w", iface, err)
+	}
+
+	return Info{
+		Name:              iface,
+		Flags:             Flags(ndi.Flags),
+		LinkMTU:           int(ndi.LinkMTU),
+		MaxMTU:            int(ndi.MaxMTU),
+		CurHopLimit:       int(ndi.CurHopLimit),
+		BaseReachableTime: time.Duration(ndi.BaseReachable) * time.Millisecond,
+		ReachableTime:     time.Duration(ndi.Reachable) * time.Second,
+		RetransTimer:      time.Duration(ndi.Retrans) * time.Millisecond,
+	}, nil
+}
+
+// SetFlags sets or clears Neighbor Discovery flags on an interface.
+//
+// This is the equivalent of "ifconfig em0 inet6 accept_rtadv" (set) and
+// "ifconfig em0 inet6 -accept_rtadv" (clear). Requires root privileges.
+//
+// Example:
+//
+//	// ifconfig epair0b inet6 -ifdisabled accept_rtadv
+//	if err := nd6.SetFlags("epair0b", nd6.FlagIfDisabled, false); err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := nd6.SetFlags("epair0b", nd6.FlagAcceptRtadv, true); err != nil {
+//		log.Fatal(err)
+//	}
+func SetFlags(iface string, flags Flags, set bool) error {
+	if err := nd6ops.SetFlags(iface, uint32(flags), set); err != nil {
+		if set {
+			return fmt.Errorf("set nd6 flags <%s> on %s: %w", flags, iface, err)
+		}
+		return fmt.Errorf("clear nd6 flags <%s> on %s: %w", flags, iface, err)
+	}
+	return nil
+}
+
+// Enable enables IPv6 on an interface by clearing FlagIfDisabled.
+//
+// Requires root privileges.
+func Enable(iface string) error {
+	return SetFlags(iface, FlagIfDisabled, false)
+}
+
+// Disable disables IPv6 on an interface by setting FlagIfDisabled.
+//
+// Requires root privileges.
+func Disable(iface string) error {
+	return SetFlags(iface, FlagIfDisabled, true)
+}
+
+// PrivacyExtensions reports whether RFC 4941 temporary addresses are
+// generated (net.inet6.ip6.use_tempaddr).
+//
+// FreeBSD controls privacy extensions system-wide, not per interface.
+func PrivacyExtensions() (bool, error) {
+	v, err := isyscall.SysctlInt(constants.SysctlUseTempAddr)
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", constants.SysctlUseTempAddr, err)
+	}
+	return v != 0, nil
+}
+
+// SetPrivacyExtensions enables or disables RFC 4941 temporary addresses.
+//
+// Both net.inet6.ip6.use_tempaddr and net.inet6.ip6.prefer_tempaddr are set,
+// so that temporary addresses are generated and preferred as source. The
+// setting is system-wide. Requires root privileges.
+func SetPrivacyExtensions(enable bool) error {
+	v := 0
+	if enable {
+		v = 1
+	}
+	for _, name := range []string{constants.SysctlUseTempAddr, constants.SysctlPreferTempAddr} {
+		if err := isyscall.SetSysctlInt(name, v); err != nil {
+			return fmt.Errorf("set %s=%d: %w", name, v, err)
+		}
+	}
+	return nil
+}
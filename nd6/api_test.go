@@ -0,0 +1,79 @@
+//go:build freebsd
+// +build freebsd
+
+package nd6
+
+import (
+	"os"
+	"testing"
+)
+
+func skipIfNotRoot(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Test requires root privileges")
+	}
+}
+
+func skipIfNotE2E(t *testing.T) {
+	if os.Getenv("IFCLIB_E2E") != "1" {
+		t.Skip("E2E tests disabled. Set IFCLIB_E2E=1 to enable")
+	}
+}
+
+// TestGet tests reading Neighbor Discovery settings of lo0
+func TestGet(t *testing.T) {
+	info, err := Get("lo0")
+	if err != nil {
+		t.Fatalf("Get(lo0) failed: %v", err)
+	}
+
+	if info.Name != "lo0" {
+		t.Errorf("Expected name 'lo0', got '%s'", info.Name)
+	}
+	if info.LinkMTU <= 0 {
+		t.Errorf("Expected positive link MTU, got %d", info.LinkMTU)
+	}
+
+	t.Logf("lo0: flags=<%s> linkmtu=%d chlim=%d reachable=%v retrans=%v",
+		info.Flags, info.LinkMTU, info.CurHopLimit, info.ReachableTime, info.RetransTimer)
+}
+
+// TestGetNonExistent tests error handling for non-existent interface
+func TestGetNonExistent(t *testing.T) {
+	_, err := Get("nonexistent999")
+	if err == nil {
+		t.Error("Get() should fail for non-existent interface")
+	}
+}
+
+// TestSetFlags tests toggling a flag and restoring it
+func TestSetFlags(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	before, err := Get("lo0")
+	if err != nil {
+		t.Fatalf("Get(lo0) failed: %v", err)
+	}
+	wasSet := before.Flags&FlagNoDAD != 0
+	defer SetFlags("lo0", FlagNoDAD, wasSet)
+
+	if err := SetFlags("lo0", FlagNoDAD, !wasSet); err != nil {
+		t.Fatalf("SetFlags() failed: %v", err)
+	}
+
+	after, err := Get("lo0")
+	if err != nil {
+		t.Fatalf("Get(lo0) failed: %v", err)
+	}
+	if (after.Flags&FlagNoDAD != 0) == wasSet {
+		t.Errorf("FlagNoDAD not toggled: before=<%s> after=<%s>", before.Flags, after.Flags)
+	}
+}
+
+// TestFlagsString tests the Flags.String method
+func TestFlagsString(t *testing.T) {
+	if s := (FlagPerformNUD | FlagAcceptRtadv).String(); s != "PERFORMNUD,ACCEPT_RTADV" {
+		t.Errorf("String() = %q, expected %q", s, "PERFORMNUD,ACCEPT_RTADV")
+	}
+}
@@ -0,0 +1,33 @@
+//go:build freebsd
+// +build freebsd
+
+package nd6
+
+import (
+	"testing"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+)
+
+// TestConstants checks the interface flags against the system headers
+func TestConstants(t *testing.T) {
+	values := []struct {
+		name      string
+		got, want int
+	}{
+		{"ND6_IFF_PERFORMNUD", int(FlagPerformNUD), constants.ND6_IFF_PERFORMNUD},
+		{"ND6_IFF_ACCEPT_RTADV", int(FlagAcceptRtadv), constants.ND6_IFF_ACCEPT_RTADV},
+		{"ND6_IFF_PREFER_SOURCE", int(FlagPreferSource), constants.ND6_IFF_PREFER_SOURCE},
+		{"ND6_IFF_IFDISABLED", int(FlagIfDisabled), constants.ND6_IFF_IFDISABLED},
+		{"ND6_IFF_DONT_SET_IFROUTE", int(FlagDontSetIfroute), constants.ND6_IFF_DONT_SET_IFROUTE},
+		{"ND6_IFF_AUTO_LINKLOCAL", int(FlagAutoLinklocal), constants.ND6_IFF_AUTO_LINKLOCAL},
+		{"ND6_IFF_NO_RADR", int(FlagNoRadr), constants.ND6_IFF_NO_RADR},
+		{"ND6_IFF_NO_PREFER_IFACE", int(FlagNoPreferIface), constants.ND6_IFF_NO_PREFER_IFACE},
+		{"ND6_IFF_NO_DAD", int(FlagNoDAD), constants.ND6_IFF_NO_DAD},
+	}
+	for _, v := range values {
+		if v.got != v.want {
+			t.Errorf("%s = %#x, want %#x", v.name, v.got, v.want)
+		}
+	}
+}
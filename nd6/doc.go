@@ -0,0 +1,55 @@
+/*
+Package nd6 provides FreeBSD per-interface IPv6 Neighbor Discovery settings.
+
+This is the "ifconfig inet6" and "ndp -i" functionality: toggling flags such
+as accept_rtadv, auto_linklocal and ifdisabled, and reading the link MTU,
+hop limit and Neighbor Discovery timers of an interface.
+
+# Basic Usage
+
+Enable IPv6 and router advertisements on a new interface:
+
+	// ifconfig epair0b inet6 -ifdisabled accept_rtadv
+	if err := nd6.Enable("epair0b"); err != nil {
+		log.Fatal(err)
+	}
+	if err := nd6.SetFlags("epair0b", nd6.FlagAcceptRtadv, true); err != nil {
+		log.Fatal(err)
+	}
+
+Query the Neighbor Discovery state:
+
+	info, err := nd6.Get("em0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("flags=<%s>\n", info.Flags)
+	fmt.Printf("linkmtu=%d chlim=%d reachable=%v retrans=%v\n",
+		info.LinkMTU, info.CurHopLimit, info.ReachableTime, info.RetransTimer)
+
+# Flags
+
+  - FlagPerformNUD: Neighbor Unreachability Detection
+  - FlagAcceptRtadv: Accept Router Advertisements (SLAAC, default routers)
+  - FlagPreferSource: Prefer addresses on this interface as source
+  - FlagIfDisabled: IPv6 is disabled on the interface
+  - FlagAutoLinklocal: Configure a link-local address automatically
+  - FlagNoRadr: Ignore default routers from Router Advertisements
+  - FlagNoPreferIface: Do not prefer this interface for source selection
+  - FlagNoDAD: Skip Duplicate Address Detection
+
+# Privacy Extensions
+
+RFC 4941 temporary addresses are controlled system-wide on FreeBSD, through
+the net.inet6.ip6.use_tempaddr and prefer_tempaddr sysctls:
+
+	if err := nd6.SetPrivacyExtensions(true); err != nil {
+		log.Fatal(err)
+	}
+
+# Permissions
+
+Get and PrivacyExtensions work without special privileges.
+SetFlags, Enable, Disable and SetPrivacyExtensions require root privileges.
+*/
+package nd6
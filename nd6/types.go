@@ -0,0 +1,62 @@
+//go:build freebsd
+// +build freebsd
+
+package nd6
+
+import (
+	"strings"
+	"time"
+)
+
+// Flags represents per-interface Neighbor Discovery flags (ND6_IFF_*).
+type Flags uint32
+
+const (
+	FlagPerformNUD     Flags = 0x1   // Perform Neighbor Unreachability Detection
+	FlagAcceptRtadv    Flags = 0x2   // Accept Router Advertisements
+	FlagPreferSource   Flags = 0x4   // Prefer addresses on this interface as source
+	FlagIfDisabled     Flags = 0x8   // IPv6 is disabled on this interface
+	FlagDontSetIfroute Flags = 0x10  // Do not install a prefix route for addresses
+	FlagAutoLinklocal  Flags = 0x20  // Configure a link-local address automatically
+	FlagNoRadr         Flags = 0x40  // Ignore default routers learned from advertisements
+	FlagNoPreferIface  Flags = 0x80  // Do not prefer this interface for source selection
+	FlagNoDAD          Flags = 0x100 // Skip Duplicate Address Detection
+)
+
+var flagNames = []struct {
+	flag Flags
+	name string
+}{
+	{FlagPerformNUD, "PERFORMNUD"},
+	{FlagAcceptRtadv, "ACCEPT_RTADV"},
+	{FlagPreferSource, "PREFER_SOURCE"},
+	{FlagIfDisabled, "IFDISABLED"},
+	{FlagDontSetIfroute, "DONT_SET_IFROUTE"},
+	{FlagAutoLinklocal, "AUTO_LINKLOCAL"},
+	{FlagNoRadr, "NO_RADR"},
+	{FlagNoPreferIface, "NO_PREFER_IFACE"},
+	{FlagNoDAD, "NO_DAD"},
+}
+
+// String returns the flag names as printed by ifconfig(8), e.g. "PERFORMNUD,ACCEPT_RTADV".
+func (f Flags) String() string {
+	var names []string
+	for _, fn := range flagNames {
+		if f&fn.flag != 0 {
+			names = append(names, fn.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Info represents the Neighbor Discovery state of an interface.
+type Info struct {
+	Name              string        // Interface name
+	Flags             Flags         // ND6_IFF_* flags
+	LinkMTU           int           // Effective IPv6 link MTU
+	MaxMTU            int           // Upper bound for the link MTU
+	CurHopLimit       int           // Hop limit for outgoing packets
+	BaseReachableTime time.Duration // Base value for the reachable time
+	ReachableTime     time.Duration // Current (randomized) reachable time
+	RetransTimer      time.Duration // Interval between Neighbor Solicitations
+}
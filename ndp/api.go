@@ -0,0 +1,186 @@
+//go:build freebsd
+// +build freebsd
+
+package ndp
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// List returns all entries of the IPv6 neighbor cache.
+//
+// This is the "ndp -an" output. Works without special privileges.
+//
+// Example:
+//
+//	entries, err := ndp.List()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, e := range entries {
+//		fmt.Printf("%s %s %s %s\n", e.IP, e.MAC, e.Interface, e.State)
+//	}
+func List() ([]Entry, error) {
+	lle, err := routing.ListLL(constants.AF_INET6)
+	if err != nil {
+		return nil, fmt.Errorf("list neighbor cache: %w", err)
+	}
+
+	names := make(map[int]string)
+
+	entries := make([]Entry, 0, len(lle))
+	for _, l := range lle {
+		name, ok := names[l.Index]
+		if !ok {
+			name, _ = ifops.NameByIndex(l.Index)
+			names[l.Index] = name
+		}
+
+		e := Entry{
+			IP:        l.IP,
+			MAC:       l.LLAddr,
+			Interface: name,
+			State:     State(l.State),
+			Router:    l.Flags&constants.RTF_GATEWAY != 0,
+			Permanent: l.Expire == 0,
+			Published: l.Flags&constants.RTF_ANNOUNCE != 0,
+		}
+		if l.Expire != 0 {
+			// Neighbor cache expiry times are wall-clock seconds
+			e.Expires = time.Unix(int64(l.Expire), 0)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// Add adds a permanent (static) neighbor cache entry.
+//
+// This is "ndp -s <ip> <mac>". The address must be on a prefix directly
+// connected to iface; for link-local addresses iface is the zone.
+// Adding an existing entry replaces it. Requires root privileges.
+//
+// Example:
+//
+//	mac, _ := net.ParseMAC("58:9c:fc:00:00:01")
+//	if err := ndp.Add("bridge0", net.ParseIP("2001:db8::10"), mac); err != nil {
+//		log.Fatal(err)
+//	}
+func Add(iface string, ip net.IP, mac net.HardwareAddr) error {
+	return add(iface, ip, mac, 0)
+}
+
+// AddProxy adds a permanent published entry (proxy-ND).
+//
+// The host answers Neighbor Solicitations for ip with mac, usually the MAC
+// address of iface itself. This is "ndp -s <ip> <mac> proxy".
+// Requires root privileges.
+func AddProxy(iface string, ip net.IP, mac net.HardwareAddr) error {
+	return add(iface, ip, mac, constants.RTF_ANNOUNCE)
+}
+
+func add(iface string, ip net.IP, mac net.HardwareAddr, flags int) error {
+	if err := validateIP(ip); err != nil {
+		return err
+	}
+	if len(mac) != 6 {
+		return isyscall.NewValidationError("mac", mac.String(), "not an Ethernet address")
+	}
+
+	ifindex, err := ifops.IndexByName(iface)
+	if err != nil {
+		return fmt.Errorf("add neighbor %s on %s: %w", ip, iface, err)
+	}
+
+	if err := routing.AddLL(routing.IPSockaddrZone(ip, ifindex), mac, ifindex, flags, 0); err != nil {
+		return fmt.Errorf("add neighbor %s at %s on %s: %w", ip, mac, iface, err)
+	}
+	return nil
+}
+
+// Delete removes a neighbor cache entry.
+//
+// If iface is empty, the interface is looked up in the cache (the first
+// match wins, so pass iface for link-local addresses).
+// This operation is idempotent - returns nil if the entry doesn't exist.
+// Requires root privileges.
+//
+// Example:
+//
+//	if err := ndp.Delete("em0", net.ParseIP("fe80::1")); err != nil {
+//		log.Fatal(err)
+//	}
+func Delete(iface string, ip net.IP) error {
+	if err := validateIP(ip); err != nil {
+		return err
+	}
+
+	if iface == "" {
+		entries, err := List()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.IP.Equal(ip) {
+				iface = e.Interface
+				break
+			}
+		}
+		if iface == "" {
+			return nil // Idempotent
+		}
+	}
+
+	ifindex, err := ifops.IndexByName(iface)
+	if err != nil {
+		return fmt.Errorf("delete neighbor %s on %s: %w", ip, iface, err)
+	}
+
+	if err := routing.DelLL(routing.IPSockaddrZone(ip, ifindex), ifindex); err != nil {
+		return fmt.Errorf("delete neighbor %s on %s: %w", ip, iface, err)
+	}
+	return nil
+}
+
+// Flush removes all neighbor cache entries of an interface, or of all
+// interfaces if iface is empty.
+//
+// Entries for the host's own addresses are kept. This is "ndp -c".
+// Requires root privileges.
+func Flush(iface string) error {
+	lle, err := routing.ListLL(constants.AF_INET6)
+	if err != nil {
+		return fmt.Errorf("flush neighbor cache: %w", err)
+	}
+
+	ifindex := 0
+	if iface != "" {
+		if ifindex, err = ifops.IndexByName(iface); err != nil {
+			return fmt.Errorf("flush neighbor cache on %s: %w", iface, err)
+		}
+	}
+
+	for _, l := range lle {
+		if l.Flags&constants.RTF_PINNED != 0 || (ifindex != 0 && l.Index != ifindex) {
+			continue
+		}
+		if err := routing.DelLL(routing.IPSockaddrZone(l.IP, l.Index), l.Index); err != nil {
+			return fmt.Errorf("flush neighbor %s: %w", l.IP, err)
+		}
+	}
+	return nil
+}
+
+func validateIP(ip net.IP) error {
+	if ip.To16() == nil || ip.To4() != nil {
+		return isyscall.NewValidationError("ip", ip.String(), "not an IPv6 address")
+	}
+	return nil
+}
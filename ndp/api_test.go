@@ -0,0 +1,208 @@
+//go:build freebsd
+// +build freebsd
+
+package ndp
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/zombocoder/go-freebsd-ifc/epair"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+	"github.com/zombocoder/go-freebsd-ifc/nd6"
+)
+
+func skipIfNotRoot(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Test requires root privileges")
+	}
+}
+
+func skipIfNotE2E(t *testing.T) {
+	if os.Getenv("IFCLIB_E2E") != "1" {
+		t.Skip("E2E tests disabled. Set IFCLIB_E2E=1 to enable")
+	}
+}
+
+// TestList tests listing the neighbor cache
+func TestList(t *testing.T) {
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+
+	for _, e := range entries {
+		if e.IP.To4() != nil {
+			t.Errorf("Entry %v is not IPv6", e.IP)
+		}
+		if e.Permanent != e.Expires.IsZero() {
+			t.Errorf("Entry %v: Permanent=%v but Expires=%v", e.IP, e.Permanent, e.Expires)
+		}
+		t.Logf("%s at %s on %s state=%s router=%v permanent=%v",
+			e.IP, e.MAC, e.Interface, e.State, e.Router, e.Permanent)
+	}
+}
+
+// TestStateString tests state names
+func TestStateString(t *testing.T) {
+	tests := []struct {
+		state State
+		want  string
+	}{
+		{StateNoState, "nostate"},
+		{StateIncomplete, "incomplete"},
+		{StateReachable, "reachable"},
+		{StateStale, "stale"},
+		{StateDelay, "delay"},
+		{StateProbe, "probe"},
+		{State(7), "state7"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("State(%d).String() = %q, want %q", int(tt.state), got, tt.want)
+		}
+	}
+}
+
+// TestAddInvalid tests argument validation
+func TestAddInvalid(t *testing.T) {
+	mac, _ := net.ParseMAC("02:00:00:00:00:01")
+
+	if err := Add("lo0", net.ParseIP("10.0.0.1"), mac); err == nil {
+		t.Error("Add() should fail for IPv4 address")
+	}
+	if err := Add("lo0", net.ParseIP("2001:db8::1"), net.HardwareAddr{1, 2, 3}); err == nil {
+		t.Error("Add() should fail for short MAC address")
+	}
+	if err := Add("nonexistent999", net.ParseIP("2001:db8::1"), mac); err == nil {
+		t.Error("Add() should fail for non-existent interface")
+	}
+}
+
+// TestAddDelete tests static and proxy entries on an epair
+func TestAddDelete(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+
+	if err := nd6.Enable(pair.A); err != nil {
+		t.Fatalf("nd6.Enable() failed: %v", err)
+	}
+	if err := ip.Add6(pair.A, net.ParseIP("2001:db8:1::1"), 64); err != nil {
+		t.Fatalf("ip.Add6() failed: %v", err)
+	}
+
+	mac, _ := net.ParseMAC("02:00:00:00:00:10")
+	static := net.ParseIP("2001:db8:1::10")
+	proxy := net.ParseIP("2001:db8:1::11")
+	linkLocal := net.ParseIP("fe80::10")
+
+	if err := Add(pair.A, static, mac); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := AddProxy(pair.A, proxy, mac); err != nil {
+		t.Fatalf("AddProxy() failed: %v", err)
+	}
+	if err := Add(pair.A, linkLocal, mac); err != nil {
+		t.Fatalf("Add() link-local failed: %v", err)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	found := make(map[string]Entry)
+	for _, e := range entries {
+		if e.Interface == pair.A {
+			found[e.IP.String()] = e
+		}
+	}
+
+	if e, ok := found[static.String()]; !ok || !e.Permanent || e.MAC.String() != mac.String() {
+		t.Errorf("Static entry not listed correctly: %+v", e)
+	}
+	if e, ok := found[proxy.String()]; !ok || !e.Published {
+		t.Errorf("Proxy entry not listed correctly: %+v", e)
+	}
+	if _, ok := found[linkLocal.String()]; !ok {
+		t.Errorf("Link-local entry not listed on %s", pair.A)
+	}
+
+	if err := Delete(pair.A, linkLocal); err != nil {
+		t.Errorf("Delete() link-local failed: %v", err)
+	}
+	if err := Delete("", static); err != nil {
+		t.Errorf("Delete() failed: %v", err)
+	}
+	if err := Delete(pair.A, static); err != nil {
+		t.Errorf("Delete() should be idempotent: %v", err)
+	}
+
+	if err := Flush(pair.A); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+	entries, _ = List()
+	for _, e := range entries {
+		if e.Interface == pair.A && e.IP.Equal(proxy) {
+			t.Errorf("Entry %v still present after Flush()", e.IP)
+		}
+	}
+}
+
+// TestRouters tests listing the default router list
+func TestRouters(t *testing.T) {
+	routers, err := Routers()
+	if err != nil {
+		t.Fatalf("Routers() failed: %v", err)
+	}
+
+	for _, r := range routers {
+		if r.IP.To4() != nil {
+			t.Errorf("Router %v is not IPv6", r.IP)
+		}
+		t.Logf("%s if=%s managed=%v other=%v pref=%s lifetime=%v expires=%v",
+			r.IP, r.Interface, r.Managed, r.Other, r.Preference, r.Lifetime, r.Expires)
+	}
+}
+
+// TestPrefixes tests listing the prefix list
+func TestPrefixes(t *testing.T) {
+	prefixes, err := Prefixes()
+	if err != nil {
+		t.Fatalf("Prefixes() failed: %v", err)
+	}
+
+	for _, p := range prefixes {
+		if ones, bits := p.Prefix.Mask.Size(); bits != 128 || ones > 128 {
+			t.Errorf("Prefix %v has invalid mask", p.Prefix)
+		}
+		t.Logf("%s if=%s onlink=%v autonomous=%v vltime=%v pltime=%v advertisers=%v",
+			p.Prefix, p.Interface, p.OnLink, p.Autonomous, p.ValidLifetime, p.PreferredLifetime, p.Advertisers)
+	}
+}
+
+// TestRouterPreferenceString tests preference names
+func TestRouterPreferenceString(t *testing.T) {
+	tests := []struct {
+		pref RouterPreference
+		want string
+	}{
+		{PreferenceMedium, "medium"},
+		{PreferenceHigh, "high"},
+		{PreferenceReserved, "rsv"},
+		{PreferenceLow, "low"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pref.String(); got != tt.want {
+			t.Errorf("RouterPreference(%d).String() = %q, want %q", tt.pref, got, tt.want)
+		}
+	}
+}
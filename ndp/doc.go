@@ -0,0 +1,89 @@
+/*
+Package ndp provides FreeBSD IPv6 neighbor cache management.
+
+This is the "ndp -an", "ndp -s", "ndp -d" and "ndp -c" functionality, the
+IPv6 counterpart of package arp. Entries are read through the NET_RT_FLAGS
+routing sysctl and changed with routing socket messages, using the same
+message code as package route.
+
+# Basic Usage
+
+List the neighbor cache:
+
+	entries, err := ndp.List()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, e := range entries {
+		router := ""
+		if e.Router {
+			router = " router"
+		}
+		fmt.Printf("%s%%%s at %s %s%s\n", e.IP, e.Interface, e.MAC, e.State, router)
+	}
+
+Pin the MAC address of a neighbor:
+
+	mac, _ := net.ParseMAC("58:9c:fc:00:00:01")
+
+	// ndp -s 2001:db8::10 58:9c:fc:00:00:01
+	if err := ndp.Add("bridge0", net.ParseIP("2001:db8::10"), mac); err != nil {
+		log.Fatal(err)
+	}
+
+	// ndp -d 2001:db8::10
+	if err := ndp.Delete("bridge0", net.ParseIP("2001:db8::10")); err != nil {
+		log.Fatal(err)
+	}
+
+Flush all entries of an interface, static ones included:
+
+	// ndp -c (restricted to em0)
+	if err := ndp.Flush("em0"); err != nil {
+		log.Fatal(err)
+	}
+
+# States
+
+  - StateIncomplete: Address resolution in progress
+  - StateReachable: Recently confirmed reachable
+  - StateStale: Reachability unknown until traffic is sent
+  - StateDelay: Waiting for upper-layer confirmation
+  - StateProbe: Sending unicast probes
+
+# Default Routers and Prefixes
+
+Hosts that accept Router Advertisements learn default routers and on-link
+prefixes. Routers and Prefixes show them ("ndp -r", "ndp -p"), which
+explains routes and addresses that were never configured explicitly:
+
+	routers, err := ndp.Routers()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range routers {
+		fmt.Printf("%s%%%s pref=%s expires %v\n", r.IP, r.Interface, r.Preference, r.Expires)
+	}
+
+	prefixes, err := ndp.Prefixes()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, p := range prefixes {
+		fmt.Printf("%s on %s onlink=%v autonomous=%v\n", p.Prefix, p.Interface, p.OnLink, p.Autonomous)
+	}
+
+FlushRouters and FlushPrefixes ("ndp -R", "ndp -P") clear both lists.
+
+# Link-Local Addresses
+
+Link-local addresses are only unique per link. The interface argument of
+Add and Delete selects the zone, and Entry.Interface reports it.
+
+# Permissions
+
+List, Routers and Prefixes work without special privileges.
+Add, AddProxy, Delete, Flush, FlushRouters and FlushPrefixes require root
+privileges.
+*/
+package ndp
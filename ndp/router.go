@@ -0,0 +1,168 @@
+//go:build freebsd
+// +build freebsd
+
+package ndp
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/nd6ops"
+)
+
+// RouterPreference is the default router preference of RFC 4191.
+type RouterPreference uint8
+
+const (
+	PreferenceMedium   RouterPreference = 0 // Default preference
+	PreferenceHigh     RouterPreference = 1
+	PreferenceReserved RouterPreference = 2 // Treated as medium
+	PreferenceLow      RouterPreference = 3
+)
+
+// String returns the preference as printed by ndp(8), e.g. "high".
+func (p RouterPreference) String() string {
+	return [...]string{"medium", "high", "rsv", "low"}[p&3]
+}
+
+// Router represents a default router learned from Router Advertisements.
+type Router struct {
+	IP         net.IP           // Router address, usually link-local
+	Interface  string           // Interface the advertisement arrived on
+	Managed    bool             // M flag: addresses available via DHCPv6
+	Other      bool             // O flag: other configuration via DHCPv6
+	Preference RouterPreference // Default router preference
+	Lifetime   time.Duration    // Router lifetime from the advertisement
+	Expires    time.Time        // Expiry time, zero if the entry never expires
+}
+
+// Prefix represents an on-link prefix learned from Router Advertisements.
+//
+// A zero lifetime means infinite.
+type Prefix struct {
+	Prefix            *net.IPNet    // The prefix
+	Interface         string        // Interface the prefix belongs to
+	OnLink            bool          // L flag: prefix is on-link
+	Autonomous        bool          // A flag: used for stateless address autoconfiguration
+	ValidLifetime     time.Duration // Valid lifetime from the advertisement
+	PreferredLifetime time.Duration // Preferred lifetime from the advertisement
+	Expires           time.Time     // Expiry time, zero if the entry never expires
+	Detached          bool          // No advertising router is reachable
+	Advertisers       []net.IP      // Routers that advertised the prefix
+}
+
+// Routers returns the default router list.
+//
+// This is the "ndp -r" output. Works without special privileges.
+//
+// Example:
+//
+//	routers, err := ndp.Routers()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, r := range routers {
+//		fmt.Printf("%s if=%s pref=%s lifetime=%v\n", r.IP, r.Interface, r.Preference, r.Lifetime)
+//	}
+func Routers() ([]Router, error) {
+	drl, err := nd6ops.DefaultRouters()
+	if err != nil {
+		return nil, fmt.Errorf("list default routers: %w", err)
+	}
+
+	routers := make([]Router, 0, len(drl))
+	for _, d := range drl {
+		name, _ := ifops.NameByIndex(d.Index)
+		r := Router{
+			IP:         net.IP(append([]byte(nil), d.Addr[:]...)),
+			Interface:  name,
+			Managed:    d.Flags&constants.ND_RA_FLAG_MANAGED != 0,
+			Other:      d.Flags&constants.ND_RA_FLAG_OTHER != 0,
+			Preference: RouterPreference((d.Flags & constants.ND_RA_FLAG_RTPREF_MASK) >> 3),
+			Lifetime:   time.Duration(d.Lifetime) * time.Second,
+		}
+		if d.Expire != 0 {
+			r.Expires = time.Unix(d.Expire, 0)
+		}
+		routers = append(routers, r)
+	}
+	return routers, nil
+}
+
+// Prefixes returns the prefix list.
+//
+// This is the "ndp -p" output. Works without special privileges.
+//
+// Example:
+//
+//	prefixes, err := ndp.Prefixes()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, p := range prefixes {
+//		fmt.Printf("%s if=%s advertised by %v\n", p.Prefix, p.Interface, p.Advertisers)
+//	}
+func Prefixes() ([]Prefix, error) {
+	prl, err := nd6ops.Prefixes()
+	if err != nil {
+		return nil, fmt.Errorf("list prefixes: %w", err)
+	}
+
+	prefixes := make([]Prefix, 0, len(prl))
+	for _, p := range prl {
+		name, _ := ifops.NameByIndex(p.Index)
+		mask := net.CIDRMask(p.PrefixLen, 128)
+		pr := Prefix{
+			Prefix: &net.IPNet{
+				IP:   net.IP(p.Addr[:]).Mask(mask),
+				Mask: mask,
+			},
+			Interface:         name,
+			OnLink:            p.OnLink,
+			Autonomous:        p.Autonomous,
+			ValidLifetime:     lifetime(p.VLTime),
+			PreferredLifetime: lifetime(p.PLTime),
+			Detached:          p.Flags&constants.NDPRF_DETACHED != 0,
+		}
+		if p.Expire != 0 {
+			pr.Expires = time.Unix(p.Expire, 0)
+		}
+		for _, a := range p.Advertisers {
+			pr.Advertisers = append(pr.Advertisers, net.IP(append([]byte(nil), a.Addr[:]...)))
+		}
+		prefixes = append(prefixes, pr)
+	}
+	return prefixes, nil
+}
+
+// FlushRouters removes all entries from the default router list.
+//
+// This is "ndp -R". Routers are learned again from the next advertisement.
+// Requires root privileges.
+func FlushRouters() error {
+	if err := nd6ops.FlushDefaultRouters(); err != nil {
+		return fmt.Errorf("flush default routers: %w", err)
+	}
+	return nil
+}
+
+// FlushPrefixes removes all entries from the prefix list, together with
+// the addresses autoconfigured from them.
+//
+// This is "ndp -P". Requires root privileges.
+func FlushPrefixes() error {
+	if err := nd6ops.FlushPrefixes(); err != nil {
+		return fmt.Errorf("flush prefixes: %w", err)
+	}
+	return nil
+}
+
+func lifetime(sec uint32) time.Duration {
+	if sec == constants.ND6_INFINITE_LIFETIME {
+		return 0
+	}
+	return time.Duration(sec) * time.Second
+}
@@ -0,0 +1,57 @@
+//go:build freebsd
+// +build freebsd
+
+package ndp
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+// State represents the Neighbor Unreachability Detection state of an entry
+// (ND6_LLINFO_*).
+type State int
+
+const (
+	StateNoState    State = -2 // No state information (static entries)
+	StateIncomplete State = 0  // Address resolution in progress
+	StateReachable  State = 1  // Recently confirmed reachable
+	StateStale      State = 2  // Reachability unknown until traffic is sent
+	StateDelay      State = 3  // Waiting for upper-layer confirmation
+	StateProbe      State = 4  // Sending unicast probes
+)
+
+// String returns the state name, e.g. "reachable".
+func (s State) String() string {
+	switch s {
+	case StateNoState:
+		return "nostate"
+	case StateIncomplete:
+		return "incomplete"
+	case StateReachable:
+		return "reachable"
+	case StateStale:
+		return "stale"
+	case StateDelay:
+		return "delay"
+	case StateProbe:
+		return "probe"
+	}
+	return "state" + strconv.Itoa(int(s))
+}
+
+// Entry represents an IPv6 neighbor cache entry.
+type Entry struct {
+	IP        net.IP           // IPv6 address, without zone
+	MAC       net.HardwareAddr // Link-layer address, nil while the entry is incomplete
+	Interface string           // Interface the entry belongs to (the zone of link-local addresses)
+	State     State            // Neighbor Unreachability Detection state
+	Router    bool             // Neighbor is a router
+	Expires   time.Time        // Expiry time, zero for permanent entries
+	Permanent bool             // Entry never expires (static)
+	Published bool             // Answered on behalf of the host (proxy-ND)
+}
+
+// Incomplete reports whether address resolution is still in progress.
+func (e Entry) Incomplete() bool { return len(e.MAC) == 0 }
@@ -6,51 +6,42 @@ package route
 import (
 	"fmt"
 	"net"
-
-	ifc "github.com/zombocoder/go-freebsd-ifc/if"
-	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	"net/netip"
 )
 
 // AddDefault4 adds an IPv4 default route
-func AddDefault4(iface string, gw net.IP) error {
+func AddDefault4(iface string, gw net.IP, opts ...Option) error {
 	if gw.To4() == nil {
 		return fmt.Errorf("not an IPv4 address: %v", gw)
 	}
 	_, defaultNet, _ := net.ParseCIDR("0.0.0.0/0")
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
-	}
-
-	return routing.ModifyRoute(true, defaultNet, gw.To4(), ifindex)
+	return Add(legacyRoute(defaultNet, gw.To4(), iface), opts...)
 }
 
 // DelDefault4 deletes an IPv4 default route
-func DelDefault4(iface string, gw net.IP) error {
+func DelDefault4(iface string, gw net.IP, opts ...Option) error {
 	if gw.To4() == nil {
 		return fmt.Errorf("not an IPv4 address: %v", gw)
 	}
 	_, defaultNet, _ := net.ParseCIDR("0.0.0.0/0")
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
+	return Delete(legacyRoute(defaultNet, gw.To4(), iface), opts...)
+}
+
+// ReplaceDefault4 switches the IPv4 default route to gw atomically, or
+// adds it if there is none (see Change)
+func ReplaceDefault4(iface string, gw net.IP, opts ...Option) error {
+	if gw.To4() == nil {
+		return fmt.Errorf("not an IPv4 address: %v", gw)
 	}
+	_, defaultNet, _ := net.ParseCIDR("0.0.0.0/0")
 
-	return routing.ModifyRoute(false, defaultNet, gw.To4(), ifindex)
+	return Change(legacyRoute(defaultNet, gw.To4(), iface), opts...)
 }
 
 // AddRoute4 adds an IPv4 route
-func AddRoute4(dst *net.IPNet, gw net.IP, iface string) error {
+func AddRoute4(dst *net.IPNet, gw net.IP, iface string, opts ...Option) error {
 	if dst.IP.To4() == nil {
 		return fmt.Errorf("not an IPv4 network: %v", dst)
 	}
@@ -58,20 +49,11 @@ func AddRoute4(dst *net.IPNet, gw net.IP, iface string) error {
 		return fmt.Errorf("not an IPv4 address: %v", gw)
 	}
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
-	}
-
-	return routing.ModifyRoute(true, dst, gw.To4(), ifindex)
+	return Add(legacyRoute(dst, gw.To4(), iface), opts...)
 }
 
 // DelRoute4 deletes an IPv4 route
-func DelRoute4(dst *net.IPNet, gw net.IP, iface string) error {
+func DelRoute4(dst *net.IPNet, gw net.IP, iface string, opts ...Option) error {
 	if dst.IP.To4() == nil {
 		return fmt.Errorf("not an IPv4 network: %v", dst)
 	}
@@ -79,58 +61,46 @@ func DelRoute4(dst *net.IPNet, gw net.IP, iface string) error {
 		return fmt.Errorf("not an IPv4 address: %v", gw)
 	}
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
-	}
-
-	return routing.ModifyRoute(false, dst, gw.To4(), ifindex)
+	return Delete(legacyRoute(dst, gw.To4(), iface), opts...)
 }
 
-// AddDefault6 adds an IPv6 default route
-func AddDefault6(iface string, gw net.IP) error {
+// AddDefault6 adds an IPv6 default route.
+//
+// A link-local gateway such as fe80::1 is scoped to iface.
+func AddDefault6(iface string, gw net.IP, opts ...Option) error {
 	if gw.To4() != nil {
 		return fmt.Errorf("not an IPv6 address: %v", gw)
 	}
 	_, defaultNet, _ := net.ParseCIDR("::/0")
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
-	}
-
-	return routing.ModifyRoute(true, defaultNet, gw, ifindex)
+	return Add(legacyRoute(defaultNet, gw, iface), opts...)
 }
 
 // DelDefault6 deletes an IPv6 default route
-func DelDefault6(iface string, gw net.IP) error {
+func DelDefault6(iface string, gw net.IP, opts ...Option) error {
 	if gw.To4() != nil {
 		return fmt.Errorf("not an IPv6 address: %v", gw)
 	}
 	_, defaultNet, _ := net.ParseCIDR("::/0")
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
+	return Delete(legacyRoute(defaultNet, gw, iface), opts...)
+}
+
+// ReplaceDefault6 switches the IPv6 default route to gw atomically, or
+// adds it if there is none (see Change).
+//
+// A link-local gateway such as fe80::1 is scoped to iface.
+func ReplaceDefault6(iface string, gw net.IP, opts ...Option) error {
+	if gw.To4() != nil {
+		return fmt.Errorf("not an IPv6 address: %v", gw)
 	}
+	_, defaultNet, _ := net.ParseCIDR("::/0")
 
-	return routing.ModifyRoute(false, defaultNet, gw, ifindex)
+	return Change(legacyRoute(defaultNet, gw, iface), opts...)
 }
 
 // AddRoute6 adds an IPv6 route
-func AddRoute6(dst *net.IPNet, gw net.IP, iface string) error {
+func AddRoute6(dst *net.IPNet, gw net.IP, iface string, opts ...Option) error {
 	if dst.IP.To4() != nil {
 		return fmt.Errorf("not an IPv6 network: %v", dst)
 	}
@@ -138,20 +108,11 @@ func AddRoute6(dst *net.IPNet, gw net.IP, iface string) error {
 		return fmt.Errorf("not an IPv6 address: %v", gw)
 	}
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
-	}
-
-	return routing.ModifyRoute(true, dst, gw, ifindex)
+	return Add(legacyRoute(dst, gw, iface), opts...)
 }
 
 // DelRoute6 deletes an IPv6 route
-func DelRoute6(dst *net.IPNet, gw net.IP, iface string) error {
+func DelRoute6(dst *net.IPNet, gw net.IP, iface string, opts ...Option) error {
 	if dst.IP.To4() != nil {
 		return fmt.Errorf("not an IPv6 network: %v", dst)
 	}
@@ -159,14 +120,23 @@ func DelRoute6(dst *net.IPNet, gw net.IP, iface string) error {
 		return fmt.Errorf("not an IPv6 address: %v", gw)
 	}
 
-	ifindex := 0
-	if iface != "" {
-		ifc, err := ifc.Get(iface)
-		if err != nil {
-			return err
-		}
-		ifindex = ifc.Index
+	return Delete(legacyRoute(dst, gw, iface), opts...)
+}
+
+// legacyRoute converts the arguments of the net.IP based functions. A
+// link-local gateway is scoped to iface.
+func legacyRoute(dst *net.IPNet, gw net.IP, iface string) Route {
+	dstIP := dst.IP
+	if ip4 := dstIP.To4(); ip4 != nil {
+		dstIP = ip4
 	}
+	addr, _ := netip.AddrFromSlice(dstIP)
+	ones, _ := dst.Mask.Size()
 
-	return routing.ModifyRoute(false, dst, gw, ifindex)
+	gwAddr, _ := netip.AddrFromSlice(gw)
+	return Route{
+		Dst:       netip.PrefixFrom(addr, ones),
+		Gateway:   gwAddr,
+		Interface: iface,
+	}
 }
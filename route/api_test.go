@@ -4,9 +4,21 @@
 package route
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
+	"net/netip"
 	"os"
+	"syscall"
 	"testing"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/epair"
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
 )
 
 func skipIfNotRoot(t *testing.T) {
@@ -158,3 +170,1102 @@ func TestInvalidDestination6(t *testing.T) {
 		t.Error("AddRoute6() should fail with IPv4 destination")
 	}
 }
+
+// TestAddDelLinkLocalGateway tests a route via a zoned link-local gateway
+func TestAddDelLinkLocalGateway(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	r := Route{
+		Dst:     netip.MustParsePrefix("2001:db8:35::/64"),
+		Gateway: netip.MustParseAddr("fe80::1%lo0"),
+	}
+
+	if err := Add(r); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := Add(r); err != nil {
+		t.Errorf("Add() should be idempotent: %v", err)
+	}
+	if err := Delete(r); err != nil {
+		t.Errorf("Delete() failed: %v", err)
+	}
+}
+
+// TestLinkLocalZone tests zone validation of link-local gateways
+func TestLinkLocalZone(t *testing.T) {
+	dst := netip.MustParsePrefix("2001:db8:35::/64")
+
+	tests := []struct {
+		name string
+		r    Route
+	}{
+		{"no zone", Route{Dst: dst, Gateway: netip.MustParseAddr("fe80::1")}},
+		{"unknown zone", Route{Dst: dst, Gateway: netip.MustParseAddr("fe80::1%nonexistent999")}},
+		{"unknown zone index", Route{Dst: dst, Gateway: netip.MustParseAddr("fe80::1%65000")}},
+		{"zone mismatch", Route{Dst: dst, Gateway: netip.MustParseAddr("fe80::1%lo0"), Interface: "nonexistent999"}},
+		{"family mismatch", Route{Dst: dst, Gateway: netip.MustParseAddr("192.0.2.1")}},
+	}
+
+	for _, tt := range tests {
+		if err := Add(tt.r); err == nil {
+			t.Errorf("%s: Add() should fail", tt.name)
+		}
+	}
+}
+
+// TestList tests dumping the routing table
+func TestList(t *testing.T) {
+	tests := []struct {
+		family ip.Family
+		dst    netip.Prefix
+	}{
+		{ip.IPv4, netip.MustParsePrefix("127.0.0.1/32")},
+		{ip.IPv6, netip.MustParsePrefix("::1/128")},
+	}
+
+	for _, tt := range tests {
+		routes, err := List(tt.family)
+		if err != nil {
+			t.Fatalf("List(%s) failed: %v", tt.family, err)
+		}
+
+		found := false
+		for _, r := range routes {
+			if r.Dst.Addr().Is4() != (tt.family == ip.IPv4) {
+				t.Errorf("List(%s) returned %s", tt.family, r.Dst)
+			}
+			if r.Dst == tt.dst {
+				found = true
+				if r.Interface != "lo0" {
+					t.Errorf("%s: expected interface lo0, got %q", r.Dst, r.Interface)
+				}
+				if r.Flags&FlagHost == 0 || r.Flags&FlagUp == 0 {
+					t.Errorf("%s: expected flags UH, got %s", r.Dst, r.Flags)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("List(%s) has no route to %s", tt.family, tt.dst)
+		}
+	}
+
+	if _, err := List(ip.Family(5)); !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for unknown family, got %v", err)
+	}
+	if _, err := List(ip.IPv4, WithFIB(-2)); !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for negative FIB, got %v", err)
+	}
+}
+
+// TestRouteFlagsString tests netstat-style flag letters
+func TestRouteFlagsString(t *testing.T) {
+	tests := []struct {
+		flags RouteFlags
+		want  string
+	}{
+		{0, ""},
+		{FlagUp | FlagGateway | FlagStatic, "UGS"},
+		{FlagUp | FlagHost | FlagStatic | FlagBlackhole, "UHSB"},
+		{FlagUp | FlagReject | FlagProto1, "UR1"},
+		{FlagUp | FlagPinned, "U"},
+	}
+	for _, tt := range tests {
+		if got := tt.flags.String(); got != tt.want {
+			t.Errorf("RouteFlags(%#x).String() = %q, want %q", uint32(tt.flags), got, tt.want)
+		}
+	}
+}
+
+// TestFromMessage tests conversion of dumped routing messages
+func TestFromMessage(t *testing.T) {
+	m := &routing.Message{
+		Type:  constants.RTM_GET,
+		Flags: int(FlagUp | FlagStatic),
+		Metrics: routing.Metrics{
+			MTU:      1400,
+			Hopcount: 3,
+			Expire:   2000000000,
+			Weight:   5,
+			Pksent:   42,
+		},
+	}
+	m.Addrs[constants.RTAX_DST] = &routing.Inet4Addr{IP: [4]byte{10, 1, 2, 0}}
+	m.Addrs[constants.RTAX_GATEWAY] = &routing.LinkAddr{Index: 7, Name: "tun0"}
+	m.Addrs[constants.RTAX_NETMASK] = &routing.Inet4Addr{IP: [4]byte{255, 255, 255, 0}}
+	m.Addrs[constants.RTAX_IFP] = &routing.LinkAddr{Index: 7, Name: "tun0"}
+	m.Addrs[constants.RTAX_IFA] = &routing.Inet4Addr{IP: [4]byte{10, 1, 2, 1}}
+
+	r, ok := fromMessage(m, ifNames{})
+	if !ok {
+		t.Fatal("fromMessage() skipped an IPv4 route")
+	}
+	if r.Dst != netip.MustParsePrefix("10.1.2.0/24") {
+		t.Errorf("Dst = %s", r.Dst)
+	}
+	if r.Gateway.IsValid() || r.GatewayLink == nil || r.GatewayLink.String() != "link#7" {
+		t.Errorf("Gateway = %s, GatewayLink = %v", r.Gateway, r.GatewayLink)
+	}
+	if r.Kind != KindInterface {
+		t.Errorf("Kind = %s, want interface", r.Kind)
+	}
+	if r.Interface != "tun0" || r.IfAddr != netip.MustParseAddr("10.1.2.1") {
+		t.Errorf("Interface = %q, IfAddr = %s", r.Interface, r.IfAddr)
+	}
+	if r.MTU != 1400 || r.Use != 42 || r.Expires.Unix() != 2000000000 {
+		t.Errorf("MTU = %d, Use = %d, Expires = %v", r.MTU, r.Use, r.Expires)
+	}
+	if r.Hopcount != 3 || r.Weight != 5 {
+		t.Errorf("Hopcount = %d, Weight = %d", r.Hopcount, r.Weight)
+	}
+
+	// Scoped gateway with an unknown interface keeps the numeric zone
+	m.Addrs[constants.RTAX_DST] = &routing.Inet6Addr{}
+	m.Addrs[constants.RTAX_NETMASK] = &routing.Inet6Addr{}
+	m.Addrs[constants.RTAX_GATEWAY] = &routing.Inet6Addr{IP: netip.MustParseAddr("fe80::1").As16(), ZoneID: 9999}
+	m.Addrs[constants.RTAX_IFA] = nil
+	r, _ = fromMessage(m, ifNames{9999: ""})
+	if r.Dst != netip.MustParsePrefix("::/0") || r.Gateway != netip.MustParseAddr("fe80::1%9999") {
+		t.Errorf("Dst = %s, Gateway = %s", r.Dst, r.Gateway)
+	}
+	if r.Kind != KindGateway {
+		t.Errorf("Kind = %s, want gateway", r.Kind)
+	}
+
+	m.Flags |= int(FlagBlackhole)
+	if r, _ = fromMessage(m, ifNames{}); r.Kind != KindBlackhole {
+		t.Errorf("Kind = %s, want blackhole", r.Kind)
+	}
+
+	// Non-IP destinations are skipped
+	m.Addrs[constants.RTAX_DST] = &routing.LinkAddr{Index: 1}
+	if _, ok := fromMessage(m, ifNames{}); ok {
+		t.Error("fromMessage() should skip link-layer destinations")
+	}
+}
+
+// TestGet tests route lookups
+func TestGet(t *testing.T) {
+	for _, dst := range []string{"127.0.0.1", "::1"} {
+		r, err := Get(netip.MustParseAddr(dst))
+		if err != nil {
+			t.Fatalf("Get(%s) failed: %v", dst, err)
+		}
+		if !r.Dst.Contains(netip.MustParseAddr(dst)) {
+			t.Errorf("Get(%s) returned route to %s", dst, r.Dst)
+		}
+		if r.Interface != "lo0" {
+			t.Errorf("Get(%s) returned interface %q, want lo0", dst, r.Interface)
+		}
+	}
+
+	if r, err := Default4(); err == nil {
+		t.Logf("IPv4 default route via %s on %s, source %s", r.Gateway, r.Interface, r.IfAddr)
+	} else if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Default4() failed: %v", err)
+	}
+	if _, err := Default6(); err != nil && !errors.Is(err, ErrNotFound) {
+		t.Errorf("Default6() failed: %v", err)
+	}
+
+	if _, err := Get(netip.Addr{}); !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for invalid address, got %v", err)
+	}
+	if _, err := Get(netip.MustParseAddr("fe80::1%nonexistent0")); err == nil {
+		t.Error("Get() should fail for unknown zone")
+	}
+}
+
+// TestWithFIB tests programming a route into a second routing table
+func TestWithFIB(t *testing.T) {
+	r := Route{Dst: netip.MustParsePrefix("198.51.100.0/24"), Gateway: netip.MustParseAddr("127.0.0.1")}
+	if err := Add(r, WithFIB(-2)); !isyscall.IsValidation(err) {
+		t.Errorf("Expected validation error for negative FIB, got %v", err)
+	}
+
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	fibs, err := isyscall.SysctlInt("net.fibs")
+	if err != nil {
+		t.Fatalf("read net.fibs: %v", err)
+	}
+	if fibs < 2 {
+		t.Skip("Test requires net.fibs >= 2")
+	}
+
+	_, dst, _ := net.ParseCIDR("198.51.100.0/24")
+	if err := AddRoute4(dst, net.ParseIP("127.0.0.1"), "lo0", WithFIB(1)); err != nil {
+		t.Fatalf("AddRoute4() with WithFIB(1) failed: %v", err)
+	}
+	defer DelRoute4(dst, net.ParseIP("127.0.0.1"), "lo0", WithFIB(1))
+
+	inFIB := func(fib int) bool {
+		routes, err := List(ip.IPv4, WithFIB(fib))
+		if err != nil {
+			t.Fatalf("List(fib %d) failed: %v", fib, err)
+		}
+		for _, rt := range routes {
+			if rt.Dst == r.Dst {
+				return true
+			}
+		}
+		return false
+	}
+	if !inFIB(1) {
+		t.Error("Route not found in FIB 1")
+	}
+	if inFIB(0) {
+		t.Error("Route leaked into FIB 0")
+	}
+
+	got, err := Get(netip.MustParseAddr("198.51.100.7"), WithFIB(1))
+	if err != nil {
+		t.Fatalf("Get() in FIB 1 failed: %v", err)
+	}
+	if got.Dst != r.Dst {
+		t.Errorf("Get() in FIB 1 returned %s, want %s", got.Dst, r.Dst)
+	}
+
+	if err := DelRoute4(dst, net.ParseIP("127.0.0.1"), "lo0", WithFIB(1)); err != nil {
+		t.Errorf("DelRoute4() with WithFIB(1) failed: %v", err)
+	}
+	if inFIB(1) {
+		t.Error("Route still in FIB 1 after delete")
+	}
+}
+
+// TestKindString tests route kind names
+func TestKindString(t *testing.T) {
+	tests := map[Kind]string{
+		KindGateway:   "gateway",
+		KindInterface: "interface",
+		KindBlackhole: "blackhole",
+		KindReject:    "reject",
+		KindLLInfo:    "llinfo",
+		Kind(42):      "kind42",
+	}
+	for k, want := range tests {
+		if got := k.String(); got != want {
+			t.Errorf("Kind(%d).String() = %q, want %q", int(k), got, want)
+		}
+	}
+}
+
+// TestKindInvalid tests validation of route kinds
+func TestKindInvalid(t *testing.T) {
+	tests := []Route{
+		{Dst: netip.MustParsePrefix("10.0.0.0/8"), Kind: KindInterface},
+		{Dst: netip.MustParsePrefix("10.0.0.0/8"), Kind: KindInterface, Interface: "lo0", Gateway: netip.MustParseAddr("127.0.0.1")},
+		{Dst: netip.MustParsePrefix("10.0.0.0/8"), Kind: KindLLInfo, Interface: "lo0"},
+		{Dst: netip.MustParsePrefix("10.0.0.1/32"), Kind: KindLLInfo},
+		{Dst: netip.MustParsePrefix("10.0.0.1/32"), Kind: KindLLInfo, Interface: "lo0"},
+		{Dst: netip.MustParsePrefix("10.0.0.0/8"), Kind: Kind(42)},
+	}
+	for _, r := range tests {
+		if err := Add(r); !isyscall.IsValidation(err) {
+			t.Errorf("Add(%s) expected validation error, got %v", describe(r), err)
+		}
+	}
+}
+
+// TestRouteKinds tests interface, blackhole, reject and LLINFO routes
+func TestRouteKinds(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	routes := []Route{
+		{Dst: netip.MustParsePrefix("198.18.0.0/24"), Kind: KindBlackhole},
+		{Dst: netip.MustParsePrefix("198.18.1.0/24"), Kind: KindReject},
+		{Dst: netip.MustParsePrefix("198.18.2.0/24"), Kind: KindInterface, Interface: "lo0"},
+		{Dst: netip.MustParsePrefix("fd00:42::/48"), Kind: KindBlackhole},
+	}
+	for _, r := range routes {
+		if err := Add(r); err != nil {
+			t.Fatalf("Add(%s) failed: %v", describe(r), err)
+		}
+		defer Delete(r)
+	}
+
+	listed := make(map[netip.Prefix]Route)
+	for _, family := range []ip.Family{ip.IPv4, ip.IPv6} {
+		all, err := List(family)
+		if err != nil {
+			t.Fatalf("List() failed: %v", err)
+		}
+		for _, r := range all {
+			listed[r.Dst] = r
+		}
+	}
+	for _, r := range routes {
+		got, ok := listed[r.Dst]
+		if !ok {
+			t.Errorf("Route %s not listed", describe(r))
+			continue
+		}
+		if got.Kind != r.Kind {
+			t.Errorf("Route %s listed as %s (flags %s)", r.Dst, got.Kind, got.Flags)
+		}
+	}
+
+	for _, r := range routes {
+		if err := Delete(r); err != nil {
+			t.Errorf("Delete(%s) failed: %v", describe(r), err)
+		}
+	}
+
+	// Static link-layer entry on an epair
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+	if err := ip.Add4(pair.A, net.ParseIP("198.18.3.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	mac, _ := net.ParseMAC("02:00:00:00:00:42")
+	ll := Route{
+		Dst:         netip.MustParsePrefix("198.18.3.42/32"),
+		Kind:        KindLLInfo,
+		Interface:   pair.A,
+		GatewayLink: &LinkAddr{MAC: mac},
+	}
+	if err := Add(ll); err != nil {
+		t.Fatalf("Add(%s) failed: %v", describe(ll), err)
+	}
+	if err := Delete(ll); err != nil {
+		t.Errorf("Delete(%s) failed: %v", describe(ll), err)
+	}
+	if err := Delete(ll); err != nil {
+		t.Errorf("Delete(%s) should be idempotent: %v", describe(ll), err)
+	}
+}
+
+// TestChange tests replacing a route's gateway in place
+func TestChange(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+	if err := ip.Add4(pair.A, net.ParseIP("198.18.4.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	r := Route{Dst: netip.MustParsePrefix("198.18.5.0/24"), Gateway: netip.MustParseAddr("198.18.4.2")}
+
+	// Change adds a missing route
+	if err := Change(r); err != nil {
+		t.Fatalf("Change() of a missing route failed: %v", err)
+	}
+	defer Delete(r)
+
+	r.Gateway = netip.MustParseAddr("198.18.4.3")
+	if err := Change(r); err != nil {
+		t.Fatalf("Change() failed: %v", err)
+	}
+
+	got, err := Get(netip.MustParseAddr("198.18.5.1"))
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.Gateway != r.Gateway {
+		t.Errorf("Gateway after Change() = %s, want %s", got.Gateway, r.Gateway)
+	}
+
+	// Replacing the real default route would break the test host
+	if err := ReplaceDefault4("lo0", net.ParseIP("::1")); err == nil {
+		t.Error("ReplaceDefault4() should fail for IPv6 gateway")
+	}
+	if err := ReplaceDefault6("lo0", net.ParseIP("127.0.0.1")); err == nil {
+		t.Error("ReplaceDefault6() should fail for IPv4 gateway")
+	}
+}
+
+// TestOptionsInvalid tests validation of route options
+func TestOptionsInvalid(t *testing.T) {
+	r := Route{Dst: netip.MustParsePrefix("198.18.6.0/24"), Gateway: netip.MustParseAddr("127.0.0.1")}
+	for name, opt := range map[string]Option{
+		"mtu":      WithMTU(0),
+		"hopcount": WithHopcount(-1),
+		"expire":   WithExpire(0),
+		"weight":   WithWeight(0),
+		"flags":    WithFlags(FlagStatic),
+	} {
+		if err := Add(r, opt); !isyscall.IsValidation(err) {
+			t.Errorf("Add() with invalid %s expected validation error, got %v", name, err)
+		}
+	}
+
+	// Link-layer entries live outside the FIBs and have no metrics
+	mac, _ := net.ParseMAC("02:00:00:00:00:42")
+	ll := Route{Dst: netip.MustParsePrefix("198.18.6.1/32"), Kind: KindLLInfo, Interface: "lo0", GatewayLink: &LinkAddr{MAC: mac}}
+	var res Result
+	for name, opt := range map[string]Option{
+		"fib":    WithFIB(1),
+		"mtu":    WithMTU(1280),
+		"flags":  WithFlags(FlagProto1),
+		"strict": WithStrict(),
+		"result": WithResult(&res),
+	} {
+		if err := Add(ll, opt); !isyscall.IsValidation(err) {
+			t.Errorf("Add(%s) with %s expected validation error, got %v", describe(ll), name, err)
+		}
+	}
+}
+
+// TestRouteMetrics tests setting and listing route metrics
+func TestRouteMetrics(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	r := Route{Dst: netip.MustParsePrefix("198.18.6.0/24"), Gateway: netip.MustParseAddr("127.0.0.1")}
+	err := Add(r,
+		WithMTU(1280),
+		WithHopcount(3),
+		WithWeight(5),
+		WithExpire(time.Hour),
+		WithFlags(FlagProto1),
+	)
+	if err != nil {
+		t.Fatalf("Add() with metrics failed: %v", err)
+	}
+	defer Delete(r)
+
+	find := func() Route {
+		routes, err := List(ip.IPv4)
+		if err != nil {
+			t.Fatalf("List() failed: %v", err)
+		}
+		for _, got := range routes {
+			if got.Dst == r.Dst {
+				return got
+			}
+		}
+		t.Fatalf("Route %s not listed", r.Dst)
+		return Route{}
+	}
+
+	got := find()
+	if got.MTU != 1280 {
+		t.Errorf("MTU = %d, want 1280", got.MTU)
+	}
+	if got.Weight != 5 {
+		t.Errorf("Weight = %d, want 5", got.Weight)
+	}
+	if got.Flags&FlagProto1 == 0 {
+		t.Errorf("Flags = %s, want proto1", got.Flags)
+	}
+	if until := time.Until(got.Expires); until <= 0 || until > time.Hour+time.Minute {
+		t.Errorf("Expires = %v, want in about an hour", got.Expires)
+	}
+
+	// Change updates metrics in place
+	if err := Change(r, WithMTU(1400)); err != nil {
+		t.Fatalf("Change() failed: %v", err)
+	}
+	if got := find(); got.MTU != 1400 {
+		t.Errorf("MTU after Change() = %d, want 1400", got.MTU)
+	}
+}
+
+// TestEventTypeString tests event type names
+func TestEventTypeString(t *testing.T) {
+	tests := map[EventType]string{
+		EventAdd:      "add",
+		EventDelete:   "delete",
+		EventChange:   "change",
+		EventMiss:     "miss",
+		EventRedirect: "redirect",
+		EventType(42): "event42",
+	}
+	for typ, want := range tests {
+		if got := typ.String(); got != want {
+			t.Errorf("EventType(%d).String() = %q, want %q", int(typ), got, want)
+		}
+	}
+}
+
+// TestEventFromMessage tests decoding of routing socket messages
+func TestEventFromMessage(t *testing.T) {
+	m := &routing.Message{
+		Type:  constants.RTM_DELETE,
+		Flags: constants.RTF_UP | constants.RTF_GATEWAY,
+		Pid:   1234,
+	}
+	m.Addrs[constants.RTAX_DST] = &routing.Inet4Addr{IP: [4]byte{198, 18, 7, 0}}
+	m.Addrs[constants.RTAX_GATEWAY] = &routing.Inet4Addr{IP: [4]byte{192, 0, 2, 1}}
+	m.Addrs[constants.RTAX_NETMASK] = &routing.Inet4Addr{IP: [4]byte{255, 255, 255, 0}}
+
+	ev, ok := eventFromMessage(m, make(ifNames))
+	if !ok {
+		t.Fatal("eventFromMessage() skipped a delete")
+	}
+	if ev.Type != EventDelete || ev.Pid != 1234 {
+		t.Errorf("Type = %s, Pid = %d", ev.Type, ev.Pid)
+	}
+	if ev.Route.Dst != netip.MustParsePrefix("198.18.7.0/24") || ev.Route.Gateway != netip.MustParseAddr("192.0.2.1") {
+		t.Errorf("Route = %s via %s", ev.Route.Dst, ev.Route.Gateway)
+	}
+
+	m.Errno = int(syscall.ESRCH)
+	if _, ok := eventFromMessage(m, make(ifNames)); ok {
+		t.Error("eventFromMessage() reported a rejected request")
+	}
+
+	m.Errno = 0
+	m.Type = constants.RTM_GET
+	if _, ok := eventFromMessage(m, make(ifNames)); ok {
+		t.Error("eventFromMessage() reported RTM_GET")
+	}
+}
+
+// TestMonitorInvalid tests validation of the monitor filter
+func TestMonitorInvalid(t *testing.T) {
+	ctx := context.Background()
+	if _, err := Monitor(ctx, MonitorFilter{Types: []EventType{EventType(42)}}); !isyscall.IsValidation(err) {
+		t.Errorf("Monitor() with unknown type expected validation error, got %v", err)
+	}
+	if _, err := Monitor(ctx, MonitorFilter{Family: ip.Family(5)}); !isyscall.IsValidation(err) {
+		t.Errorf("Monitor() with invalid family expected validation error, got %v", err)
+	}
+	if _, err := Monitor(ctx, MonitorFilter{}, WithFIB(-2)); !isyscall.IsValidation(err) {
+		t.Errorf("Monitor() with invalid FIB expected validation error, got %v", err)
+	}
+}
+
+// TestMonitor tests that route changes are reported
+func TestMonitor(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events, err := Monitor(ctx, MonitorFilter{
+		Types:  []EventType{EventAdd, EventDelete},
+		Family: ip.IPv4,
+	})
+	if err != nil {
+		t.Fatalf("Monitor() failed: %v", err)
+	}
+
+	r := Route{Dst: netip.MustParsePrefix("198.18.7.0/24"), Gateway: netip.MustParseAddr("127.0.0.1")}
+	if err := Add(r); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := Delete(r); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+
+	for _, want := range []EventType{EventAdd, EventDelete} {
+		for {
+			ev, ok := <-events
+			if !ok {
+				t.Fatalf("No %s event for %s", want, r.Dst)
+			}
+			if ev.Err != nil {
+				t.Fatalf("Monitor failed: %v", ev.Err)
+			}
+			if ev.Route.Dst != r.Dst {
+				continue
+			}
+			if ev.Type != want || ev.Pid != os.Getpid() {
+				t.Errorf("Event = %s by %d, want %s by %d", ev.Type, ev.Pid, want, os.Getpid())
+			}
+			break
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+// TestGroupNexthops tests merging of multipath routes from a dump
+func TestGroupNexthops(t *testing.T) {
+	dst := netip.MustParsePrefix("198.18.8.0/24")
+	gw1 := netip.MustParseAddr("198.18.9.2")
+	gw2 := netip.MustParseAddr("198.18.9.3")
+	routes := []Route{
+		{Dst: netip.MustParsePrefix("198.18.9.0/24"), GatewayLink: &LinkAddr{Index: 1}, Kind: KindInterface},
+		{Dst: dst, Gateway: gw1, Interface: "epair0a", Weight: 20},
+		{Dst: dst, Gateway: gw2, Interface: "epair0a", Weight: 10},
+		{Dst: netip.MustParsePrefix("198.18.10.0/24"), Gateway: gw1},
+	}
+
+	got := groupNexthops(routes)
+	if len(got) != 3 {
+		t.Fatalf("groupNexthops() returned %d routes, want 3", len(got))
+	}
+	if len(got[0].Nexthops) != 0 || len(got[2].Nexthops) != 0 {
+		t.Error("Single path routes should have no Nexthops")
+	}
+	want := []Nexthop{
+		{Gateway: gw1, Interface: "epair0a", Weight: 20},
+		{Gateway: gw2, Interface: "epair0a", Weight: 10},
+	}
+	if len(got[1].Nexthops) != len(want) {
+		t.Fatalf("Nexthops = %v, want %v", got[1].Nexthops, want)
+	}
+	for i := range want {
+		if got[1].Nexthops[i] != want[i] {
+			t.Errorf("Nexthops[%d] = %v, want %v", i, got[1].Nexthops[i], want[i])
+		}
+	}
+	if got[1].Gateway.IsValid() || got[1].Interface != "" || got[1].Weight != 0 {
+		t.Errorf("Multipath route has Gateway %s, Interface %q, Weight %d, want them unset",
+			got[1].Gateway, got[1].Interface, got[1].Weight)
+	}
+}
+
+// TestNexthopString tests nexthop formatting
+func TestNexthopString(t *testing.T) {
+	nh := Nexthop{Gateway: netip.MustParseAddr("192.0.2.1"), Interface: "em0", Weight: 10}
+	if got := nh.String(); got != "192.0.2.1 em0 weight 10" {
+		t.Errorf("String() = %q", got)
+	}
+	nh = Nexthop{Gateway: netip.MustParseAddr("fe80::1%em0")}
+	if got := nh.String(); got != "fe80::1%em0" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// TestNexthopsInvalid tests validation of multipath routes
+func TestNexthopsInvalid(t *testing.T) {
+	dst := netip.MustParsePrefix("198.18.8.0/24")
+	gw := netip.MustParseAddr("198.18.9.2")
+
+	tests := map[string]Route{
+		"gateway and nexthops": {Dst: dst, Gateway: gw, Nexthops: []Nexthop{{Gateway: gw}}},
+		"missing gateway":      {Dst: dst, Nexthops: []Nexthop{{Interface: "lo0"}}},
+		"weight":               {Dst: dst, Nexthops: []Nexthop{{Gateway: gw, Weight: -1}}},
+		"kind":                 {Dst: dst, Kind: KindBlackhole, Nexthops: []Nexthop{{Gateway: gw}}},
+	}
+	for name, r := range tests {
+		if err := Add(r); !isyscall.IsValidation(err) {
+			t.Errorf("Add() with invalid %s expected validation error, got %v", name, err)
+		}
+	}
+
+	if err := Change(Route{Dst: dst, Nexthops: []Nexthop{{Gateway: gw}}}); !isyscall.IsValidation(err) {
+		t.Errorf("Change() with nexthops expected validation error, got %v", err)
+	}
+}
+
+// TestMultipath tests adding, listing and removing nexthops
+func TestMultipath(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+	if v, err := isyscall.SysctlInt(constants.SysctlRouteMultipath); err != nil || v == 0 {
+		t.Skip("Test requires net.route.multipath=1")
+	}
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+	if err := ip.Add4(pair.A, net.ParseIP("198.18.9.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	dst := netip.MustParsePrefix("198.18.8.0/24")
+	nh1 := Nexthop{Gateway: netip.MustParseAddr("198.18.9.2"), Weight: 20}
+	nh2 := Nexthop{Gateway: netip.MustParseAddr("198.18.9.3"), Weight: 10}
+	if err := Add(Route{Dst: dst, Nexthops: []Nexthop{nh1, nh2}}); err != nil {
+		t.Fatalf("Add() multipath failed: %v", err)
+	}
+	defer Delete(Route{Dst: dst, Nexthops: []Nexthop{nh1, nh2}})
+
+	find := func() Route {
+		routes, err := List(ip.IPv4)
+		if err != nil {
+			t.Fatalf("List() failed: %v", err)
+		}
+		for _, r := range routes {
+			if r.Dst == dst {
+				return r
+			}
+		}
+		t.Fatalf("Route %s not listed", dst)
+		return Route{}
+	}
+
+	weights := func(r Route) map[netip.Addr]int {
+		w := make(map[netip.Addr]int)
+		for _, nh := range r.Nexthops {
+			w[nh.Gateway] = nh.Weight
+		}
+		return w
+	}
+
+	got := weights(find())
+	if len(got) != 2 || got[nh1.Gateway] != 20 || got[nh2.Gateway] != 10 {
+		t.Errorf("Nexthops = %v, want %s and %s", got, nh1, nh2)
+	}
+
+	// A third nexthop leaves the others alone
+	nh3 := Nexthop{Gateway: netip.MustParseAddr("198.18.9.4")}
+	if err := AddNexthop(dst, nh3); err != nil {
+		t.Fatalf("AddNexthop() failed: %v", err)
+	}
+	if got := weights(find()); len(got) != 3 || got[nh1.Gateway] != 20 {
+		t.Errorf("Nexthops after AddNexthop() = %v", got)
+	}
+
+	if err := DelNexthop(dst, nh1); err != nil {
+		t.Fatalf("DelNexthop() failed: %v", err)
+	}
+	got = weights(find())
+	if _, ok := got[nh1.Gateway]; ok || len(got) != 2 {
+		t.Errorf("Nexthops after DelNexthop() = %v", got)
+	}
+
+	// A listed multipath route can be deleted as it is
+	if err := Delete(find()); err != nil {
+		t.Fatalf("Delete() of a listed multipath route failed: %v", err)
+	}
+	if _, err := findRoute(dst, 0, DefaultFIB); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Route %s still exists after Delete(): %v", dst, err)
+	}
+
+	// A failed add leaves no nexthops behind
+	bad := Nexthop{Gateway: netip.MustParseAddr("198.18.9.5"), Interface: "nonexistent0"}
+	if err := Add(Route{Dst: dst, Nexthops: []Nexthop{nh1, bad}}); err == nil {
+		t.Fatal("Add() with an unknown interface should fail")
+	}
+	if _, err := findRoute(dst, 0, DefaultFIB); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Add() failure left route %s behind: %v", dst, err)
+	}
+
+	// Change replaces all nexthops instead of joining the new gateway
+	if err := Add(Route{Dst: dst, Nexthops: []Nexthop{nh1, nh2}}); err != nil {
+		t.Fatalf("Add() multipath failed: %v", err)
+	}
+	r := Route{Dst: dst, Gateway: nh3.Gateway}
+	if err := Change(r); err != nil {
+		t.Fatalf("Change() of a multipath route failed: %v", err)
+	}
+	defer Delete(r)
+	if got := find(); len(got.Nexthops) != 0 || got.Gateway != nh3.Gateway {
+		t.Errorf("Route after Change() = %+v, want single path via %s", got, nh3.Gateway)
+	}
+}
+
+// TestExistsError tests the error of strict adds
+func TestExistsError(t *testing.T) {
+	err := fmt.Errorf("add route: %w", &ExistsError{Route: Route{
+		Dst:     netip.MustParsePrefix("198.18.11.0/24"),
+		Gateway: netip.MustParseAddr("198.18.12.2"),
+	}})
+	if !errors.Is(err, ErrExists) {
+		t.Errorf("errors.Is(%v, ErrExists) = false", err)
+	}
+	var exists *ExistsError
+	if !errors.As(err, &exists) || exists.Route.Gateway != netip.MustParseAddr("198.18.12.2") {
+		t.Errorf("errors.As() did not return the existing route")
+	}
+	if got, want := exists.Error(), "resource already exists: 198.18.11.0/24 via 198.18.12.2"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+// TestStrict tests strict add and delete
+func TestStrict(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+	if err := ip.Add4(pair.A, net.ParseIP("198.18.12.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	r := Route{Dst: netip.MustParsePrefix("198.18.11.0/24"), Gateway: netip.MustParseAddr("198.18.12.2")}
+	if err := Add(r, WithStrict()); err != nil {
+		t.Fatalf("Add() strict failed: %v", err)
+	}
+	defer Delete(r)
+
+	other := r
+	other.Gateway = netip.MustParseAddr("198.18.12.3")
+	err = Add(other, WithStrict())
+	var exists *ExistsError
+	if !errors.As(err, &exists) {
+		t.Fatalf("Add() strict of an existing route expected ExistsError, got %v", err)
+	}
+	if exists.Route.Gateway != r.Gateway {
+		t.Errorf("Existing gateway = %s, want %s", exists.Route.Gateway, r.Gateway)
+	}
+
+	if err := Delete(other, WithStrict()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() strict with another gateway expected ErrNotFound, got %v", err)
+	}
+	if err := Delete(r, WithStrict()); err != nil {
+		t.Fatalf("Delete() strict failed: %v", err)
+	}
+	if err := Delete(r, WithStrict()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() strict of a missing route expected ErrNotFound, got %v", err)
+	}
+	if err := Delete(r); err != nil {
+		t.Errorf("Delete() of a missing route should be idempotent, got %v", err)
+	}
+}
+
+// TestPlanSync tests the comparison of the table with the desired routes
+func TestPlanSync(t *testing.T) {
+	gw := netip.MustParseAddr("198.18.12.2")
+	other := netip.MustParseAddr("198.18.12.3")
+	static := FlagUp | FlagGateway | FlagStatic | FlagProto1
+	current := []Route{
+		{Dst: netip.MustParsePrefix("198.18.12.0/24"), Kind: KindInterface, Interface: "em0", Flags: FlagUp},
+		{Dst: netip.MustParsePrefix("198.18.12.1/32"), Kind: KindInterface, Interface: "lo0", Flags: FlagUp | FlagHost | FlagStatic | FlagPinned},
+		{Dst: netip.MustParsePrefix("198.18.13.0/24"), Gateway: gw, Interface: "em0", Weight: 1, Flags: static},
+		{Dst: netip.MustParsePrefix("198.18.14.0/24"), Gateway: gw, Interface: "em0", Weight: 1, Flags: static},
+		{Dst: netip.MustParsePrefix("198.18.15.0/24"), Gateway: gw, Interface: "em0", Weight: 1, Flags: static},
+		{Dst: netip.MustParsePrefix("198.18.16.0/24"), Gateway: gw, Interface: "em0", Weight: 1, Flags: FlagUp | FlagGateway | FlagStatic},
+	}
+	desired := []Route{
+		{Dst: netip.MustParsePrefix("198.18.13.0/24"), Gateway: gw},
+		{Dst: netip.MustParsePrefix("198.18.14.0/24"), Gateway: other},
+		{Dst: netip.MustParsePrefix("198.18.17.0/24"), Nexthops: []Nexthop{{Gateway: gw}, {Gateway: other}}},
+	}
+
+	p, err := planSync(current, desired, Scope{Flags: FlagProto1})
+	if err != nil {
+		t.Fatalf("planSync() failed: %v", err)
+	}
+	want := "add 198.18.17.0/24 via 198.18.12.2, 198.18.12.3\n" +
+		"change 198.18.14.0/24 via 198.18.12.3\n" +
+		"delete 198.18.15.0/24 via 198.18.12.2 on em0\n"
+	if got := p.String(); got != want {
+		t.Errorf("Plan:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Scoped by interface only, unmarked static routes are managed but the
+	// interface's own routes still are not
+	p, err = planSync(current, desired, Scope{Interface: "em0"})
+	if err != nil {
+		t.Fatalf("planSync() failed: %v", err)
+	}
+	if len(p.Delete) != 2 || p.Delete[1].Dst != netip.MustParsePrefix("198.18.16.0/24") {
+		t.Errorf("Delete = %v, want 198.18.15.0/24 and 198.18.16.0/24", p.Delete)
+	}
+
+	p, err = planSync(current, desired[:1], Scope{Interface: "em1"})
+	if err != nil {
+		t.Fatalf("planSync() failed: %v", err)
+	}
+	if len(p.Change) != 0 || len(p.Delete) != 0 || len(p.Add) != 1 || p.Add[0].Interface != "em1" {
+		t.Errorf("Plan for em1:\n%s", p)
+	}
+
+	inSync := []Route{
+		{Dst: netip.MustParsePrefix("198.18.13.0/24"), Gateway: gw},
+		{Dst: netip.MustParsePrefix("198.18.14.0/24"), Gateway: gw, Interface: "em0"},
+		{Dst: netip.MustParsePrefix("198.18.15.0/24"), Nexthops: []Nexthop{{Gateway: gw, Weight: 1}}},
+	}
+	p, err = planSync(current, inSync, Scope{Flags: FlagProto1})
+	if err != nil {
+		t.Fatalf("planSync() failed: %v", err)
+	}
+	if !p.Empty() {
+		t.Errorf("Empty() = false for\n%s", p)
+	}
+}
+
+// TestSyncInvalid tests desired routes Sync rejects
+func TestSyncInvalid(t *testing.T) {
+	dst := netip.MustParsePrefix("198.18.11.0/24")
+	gw := netip.MustParseAddr("198.18.12.2")
+	tests := []struct {
+		name    string
+		desired []Route
+		scope   Scope
+	}{
+		{"invalid dst", []Route{{Gateway: gw}}, Scope{Flags: FlagProto1}},
+		{"duplicate", []Route{{Dst: dst, Gateway: gw}, {Dst: netip.MustParsePrefix("198.18.11.1/24"), Gateway: gw}}, Scope{Flags: FlagProto1}},
+		{"family", []Route{{Dst: dst, Gateway: gw}}, Scope{Family: ip.IPv6, Flags: FlagProto1}},
+		{"llinfo", []Route{{Dst: netip.MustParsePrefix("198.18.12.2/32"), Kind: KindLLInfo, Interface: "lo0"}}, Scope{Flags: FlagProto1}},
+		{"interface", []Route{{Dst: dst, Gateway: gw, Interface: "lo0"}}, Scope{Interface: "em0"}},
+		{"flags", []Route{{Dst: dst, Gateway: gw}}, Scope{Flags: FlagStatic}},
+		{"unscoped", []Route{{Dst: dst, Gateway: gw}}, Scope{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PlanSync(tt.desired, tt.scope); !isyscall.IsValidation(err) {
+				t.Errorf("PlanSync() expected validation error, got %v", err)
+			}
+		})
+	}
+
+	scope := Scope{Flags: FlagProto1}
+	for name, opt := range map[string]Option{
+		"fib":    WithFIB(-2),
+		"mtu":    WithMTU(1280),
+		"strict": WithStrict(),
+	} {
+		if _, err := PlanSync(nil, scope, opt); !isyscall.IsValidation(err) {
+			t.Errorf("PlanSync() with %s expected validation error, got %v", name, err)
+		}
+	}
+}
+
+// TestSync tests synchronising marked routes
+func TestSync(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+	if err := ip.Add4(pair.A, net.ParseIP("198.18.12.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	scope := Scope{Family: ip.IPv4, Flags: FlagProto3}
+	gw := netip.MustParseAddr("198.18.12.2")
+	a := Route{Dst: netip.MustParsePrefix("198.18.13.0/24"), Gateway: gw}
+	b := Route{Dst: netip.MustParsePrefix("198.18.14.0/24"), Gateway: gw}
+	defer Delete(a)
+	defer Delete(b)
+
+	// A route of someone else is not touched
+	foreign := Route{Dst: netip.MustParsePrefix("198.18.15.0/24"), Gateway: gw}
+	if err := Add(foreign); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	defer Delete(foreign)
+
+	if _, err := Sync([]Route{a, b}, scope); err != nil {
+		t.Fatalf("Sync() failed: %v", err)
+	}
+	p, err := PlanSync([]Route{a, b}, scope)
+	if err != nil {
+		t.Fatalf("PlanSync() failed: %v", err)
+	}
+	if !p.Empty() {
+		t.Errorf("Plan after Sync() is not empty:\n%s", p)
+	}
+
+	b.Gateway = netip.MustParseAddr("198.18.12.3")
+	p, err = Sync([]Route{b}, scope)
+	if err != nil {
+		t.Fatalf("Sync() failed: %v", err)
+	}
+	if len(p.Change) != 1 || len(p.Delete) != 1 {
+		t.Errorf("Sync() plan:\n%s\nwant one change and one delete", p)
+	}
+	if _, err := findRoute(a.Dst, 0, DefaultFIB); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Route %s still exists after Sync(): %v", a.Dst, err)
+	}
+	if r, err := findRoute(b.Dst, 0, DefaultFIB); err != nil || r.Gateway != b.Gateway {
+		t.Errorf("Route %s = %v, %v, want gateway %s", b.Dst, r, err, b.Gateway)
+	}
+	if _, err := findRoute(foreign.Dst, 0, DefaultFIB); err != nil {
+		t.Errorf("Sync() removed a route outside its scope: %v", err)
+	}
+
+	if _, err := Sync([]Route{foreign}, scope); !errors.Is(err, ErrExists) {
+		t.Errorf("Sync() over a foreign route expected ErrExists, got %v", err)
+	}
+
+	// A multipath route must not join the foreign route either
+	if v, err := isyscall.SysctlInt(constants.SysctlRouteMultipath); err != nil || v == 0 {
+		t.Skip("Multipath case requires net.route.multipath=1")
+	}
+	multipath := Route{Dst: foreign.Dst, Nexthops: []Nexthop{{Gateway: gw}, {Gateway: netip.MustParseAddr("198.18.12.3")}}}
+	if _, err := Sync([]Route{multipath}, scope); !errors.Is(err, ErrExists) {
+		t.Errorf("Sync() of a multipath route over a foreign route expected ErrExists, got %v", err)
+	}
+	routes, err := List(ip.IPv4)
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	for _, r := range routes {
+		if r.Dst == foreign.Dst && len(r.Nexthops) != 0 {
+			t.Errorf("Foreign route became multipath: %s", describe(r))
+		}
+	}
+}
+
+// TestResult tests the kernel's replies to route operations
+func TestResult(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	pair, err := epair.Create()
+	if err != nil {
+		t.Fatalf("epair.Create() failed: %v", err)
+	}
+	defer epair.Destroy(pair.A)
+	if err := ip.Add4(pair.A, net.ParseIP("198.18.12.1"), net.CIDRMask(24, 32)); err != nil {
+		t.Fatalf("ip.Add4() failed: %v", err)
+	}
+
+	r := Route{Dst: netip.MustParsePrefix("198.18.11.0/24"), Gateway: netip.MustParseAddr("198.18.12.2")}
+	var res Result
+	if err := Add(r, WithResult(&res)); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	defer Delete(r)
+	if res.Errno != 0 {
+		t.Errorf("Add() result errno = %v, want 0", res.Errno)
+	}
+	if res.Route.Dst != r.Dst || res.Route.Gateway != r.Gateway || res.Route.Interface != pair.A {
+		t.Errorf("Add() resolved %s, want %s on %s", describe(res.Route), describe(r), pair.A)
+	}
+
+	// Idempotent add succeeds but reports the kernel's errno
+	res = Result{}
+	if err := Add(r, WithResult(&res)); err != nil {
+		t.Fatalf("Add() of an existing route failed: %v", err)
+	}
+	if res.Errno != syscall.EEXIST {
+		t.Errorf("Add() of an existing route errno = %v, want EEXIST", res.Errno)
+	}
+
+	if err := Delete(r, WithResult(&res)); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if res.Errno != 0 || res.Route.Dst != r.Dst {
+		t.Errorf("Delete() result = %v %s, want the deleted route", res.Errno, describe(res.Route))
+	}
+
+	err = Delete(r, WithStrict())
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, syscall.ESRCH) {
+		t.Errorf("Delete() strict of a missing route expected ErrNotFound and ESRCH, got %v", err)
+	}
+}
+
+// TestConcurrentRequests tests that concurrent requests get their own
+// replies
+func TestConcurrentRequests(t *testing.T) {
+	dsts := []netip.Addr{netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")}
+	errs := make(chan error, 64)
+	for i := 0; i < cap(errs); i++ {
+		dst := dsts[i%len(dsts)]
+		go func() {
+			r, err := Get(dst)
+			if err == nil && !r.Dst.Contains(dst) {
+				err = fmt.Errorf("Get(%s) returned route to %s", dst, r.Dst)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
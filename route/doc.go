@@ -59,9 +59,160 @@ Specific IPv6 routes:
 		log.Fatal(err)
 	}
 
+# Routes and Link-Local Gateways
+
+Add and Delete take a Route built from netip types. Link-local gateways
+are only meaningful on one link, so they need a zone, given as part of the
+address or through Interface:
+
+	// route add -inet6 default fe80::1%em0
+	err := route.Add(route.Route{
+		Dst:     netip.MustParsePrefix("::/0"),
+		Gateway: netip.MustParseAddr("fe80::1%em0"),
+	})
+
+The net.IP based functions scope link-local gateways to their iface
+argument, so AddDefault6("em0", net.ParseIP("fe80::1")) is equivalent.
+
+# Changing Routes
+
+Deleting and re-adding a route leaves a moment without it. Change swaps
+the gateway, interface or kind in one kernel operation (RTM_CHANGE), and
+adds the route if it does not exist:
+
+	// Uplink failover without a window lacking a default route
+	err := route.ReplaceDefault4("em1", net.ParseIP("198.51.100.1"))
+
+# Route Kinds
+
+Route.Kind selects routes that do not forward to a gateway:
+
+	// route add -net 10.8.0.0/16 -interface tun0
+	err := route.Add(route.Route{
+		Dst:       netip.MustParsePrefix("10.8.0.0/16"),
+		Interface: "tun0",
+		Kind:      route.KindInterface,
+	})
+
+	// route add -net 10.0.0.0/8 127.0.0.1 -blackhole
+	err = route.Add(route.Route{Dst: netip.MustParsePrefix("10.0.0.0/8"), Kind: route.KindBlackhole})
+
+KindReject drops packets with an ICMP unreachable instead, and KindLLInfo
+adds a static link-layer entry for a host, taking the MAC address from
+GatewayLink. List reports the kind of every route.
+
+# Multiple Routing Tables
+
+FreeBSD keeps several routing tables (FIBs) when the net.fibs tunable is
+greater than one. Every function takes WithFIB to use a table other than
+the process's:
+
+	// setfib 3 route add -net 10.0.0.0/8 192.0.2.1
+	_, dst, _ := net.ParseCIDR("10.0.0.0/8")
+	err := route.AddRoute4(dst, net.ParseIP("192.0.2.1"), "", route.WithFIB(3))
+
+	routes, err := route.List(ip.IPv4, route.WithFIB(3))
+
+# Multipath Routes
+
+With the kernel's multipath support (FreeBSD 13 and later,
+net.route.multipath=1) a destination can have several weighted nexthops.
+Add and Delete take them in Route.Nexthops; AddNexthop and DelNexthop
+change one path without touching the others:
+
+	dst := netip.MustParsePrefix("0.0.0.0/0")
+	err := route.Add(route.Route{Dst: dst, Nexthops: []route.Nexthop{
+		{Gateway: netip.MustParseAddr("192.0.2.1"), Weight: 20},
+		{Gateway: netip.MustParseAddr("198.51.100.1"), Weight: 10},
+	}})
+
+	// Take the second uplink out of rotation
+	err = route.DelNexthop(dst, route.Nexthop{Gateway: netip.MustParseAddr("198.51.100.1")})
+
+List returns a multipath route once, with all its Nexthops.
+
+# Route Metrics
+
+WithMTU, WithHopcount, WithExpire and WithWeight set route metrics like
+the -mtu, -hopcount, -expire and -weight arguments of route(8). WithFlags
+sets FlagFixedMTU and the protocol flags FlagProto1 to FlagProto3 that
+routing daemons use to tag their routes:
+
+	err := route.Add(route.Route{
+		Dst:     netip.MustParsePrefix("10.8.0.0/16"),
+		Gateway: netip.MustParseAddr("192.0.2.1"),
+	}, route.WithMTU(1400), route.WithExpire(10*time.Minute), route.WithFlags(route.FlagProto1))
+
+Change updates the metrics of an existing route. List reports them in
+Route.MTU, Route.Hopcount, Route.Weight and Route.Expires.
+
+# Listing Routes
+
+List dumps a routing table like netstat -rn. Directly connected routes
+have a link-layer gateway in GatewayLink instead of Gateway:
+
+	routes, err := route.List(ip.IPv6)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range routes {
+		fmt.Println(r.Dst, r.Gateway, r.Flags, r.Interface)
+	}
+
+# Route Lookup
+
+Get asks the kernel which route, interface and source address it uses for
+a destination, like route -n get:
+
+	r, err := route.Get(netip.MustParseAddr("198.51.100.7"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(r.Interface, r.Gateway, r.IfAddr)
+
+Default4 and Default6 return the default routes.
+
+# Monitoring Routes
+
+Monitor streams route changes like route monitor. The kernel filters the
+messages by type, address family and routing table; Event.Pid tells
+changes made by other processes from the caller's own:
+
+	events, err := route.Monitor(ctx, route.MonitorFilter{
+		Types: []route.EventType{route.EventAdd, route.EventDelete, route.EventChange},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for ev := range events {
+		if ev.Err != nil {
+			log.Fatal(ev.Err)
+		}
+		fmt.Println(ev.Type, ev.Route.Dst, ev.Route.Gateway, ev.Pid)
+	}
+
+# Synchronising Routes
+
+Sync makes a routing table match a desired set of routes. It lists the
+routes in a Scope, adds missing ones, changes those with another gateway
+and deletes the rest. Mark the routes of an application with a protocol
+flag so Sync never removes routes it does not own:
+
+	scope := route.Scope{Family: ip.IPv4, Flags: route.FlagProto1}
+	plan, err := route.Sync(desired, scope)
+	if err != nil {
+		log.Print(err)
+	}
+	if !plan.Empty() {
+		log.Printf("routes updated:\n%s", plan)
+	}
+
+PlanSync computes the plan without applying it, for dry runs.
+
 # Permissions
 
-All operations require root privileges.
+List, Get, Default4, Default6 and Monitor work without special privileges. All
+other operations require root privileges.
 
 # Idempotency
 
@@ -69,6 +220,35 @@ Operations are idempotent:
   - Add: Returns nil if route already exists
   - Del: Returns nil if route doesn't exist
 
+WithStrict reports these cases instead, so that a route installed by
+someone else is not mistaken for success. Add returns an *ExistsError
+with the existing route, Delete returns ErrNotFound:
+
+	err := route.Add(r, route.WithStrict())
+	var exists *route.ExistsError
+	if errors.As(err, &exists) && exists.Route.Gateway != r.Gateway {
+		log.Printf("%s is routed via %s", r.Dst, exists.Route.Gateway)
+	}
+
+# Kernel Replies
+
+Every add, delete and change waits for the kernel's reply, matched by
+process id and a sequence number unique to the request. A rejected
+request returns an error matching both the package's error and the
+kernel's errno:
+
+	err := route.Add(r)
+	if errors.Is(err, syscall.ENETUNREACH) {
+		log.Printf("gateway %s is not on a connected network", r.Gateway)
+	}
+
+WithResult returns the reply itself: the errno, also for idempotent
+operations that succeed, and the route as the kernel resolved it:
+
+	var res route.Result
+	err := route.Add(r, route.WithResult(&res))
+	fmt.Println(res.Errno == syscall.EEXIST, res.Route.Interface)
+
 # Safety Warning
 
 Modifying routes can break network connectivity. Test in isolated environments
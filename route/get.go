@@ -0,0 +1,119 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Get returns the route the kernel uses to reach dst, like route -n get.
+//
+// The returned Route holds the matching destination prefix, the gateway,
+// the outgoing interface and in IfAddr the source address the kernel
+// would use. A link-local dst needs a zone. The lookup uses the process's
+// routing table, or the table selected with WithFIB.
+//
+// Returns ErrNotFound if no route matches. Works without special
+// privileges.
+//
+// Example:
+//
+//	// Which uplink does traffic to the peer use?
+//	r, err := route.Get(netip.MustParseAddr("198.51.100.7"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("%s via %s dev %s src %s\n", r.Dst, r.Gateway, r.Interface, r.IfAddr)
+func Get(dst netip.Addr, opts ...Option) (Route, error) {
+	if !dst.IsValid() {
+		return Route{}, isyscall.NewValidationError("dst", dst.String(), "invalid address")
+	}
+	o, err := buildOptions(opts)
+	if err != nil {
+		return Route{}, err
+	}
+	r, err := lookup(netip.PrefixFrom(dst.Unmap(), dst.Unmap().BitLen()), dst.Zone(), o.fib)
+	if err != nil {
+		return Route{}, fmt.Errorf("get route to %s: %w", dst, err)
+	}
+	return r, nil
+}
+
+// Default4 returns the IPv4 default route of the process's routing
+// table, or of the table selected with WithFIB.
+//
+// Returns ErrNotFound if there is no default route.
+func Default4(opts ...Option) (Route, error) {
+	o, err := buildOptions(opts)
+	if err != nil {
+		return Route{}, err
+	}
+	r, err := lookup(netip.MustParsePrefix("0.0.0.0/0"), "", o.fib)
+	if err != nil {
+		return Route{}, fmt.Errorf("get IPv4 default route: %w", err)
+	}
+	return r, nil
+}
+
+// Default6 returns the IPv6 default route of the process's routing
+// table, or of the table selected with WithFIB.
+//
+// Returns ErrNotFound if there is no default route.
+func Default6(opts ...Option) (Route, error) {
+	o, err := buildOptions(opts)
+	if err != nil {
+		return Route{}, err
+	}
+	r, err := lookup(netip.MustParsePrefix("::/0"), "", o.fib)
+	if err != nil {
+		return Route{}, fmt.Errorf("get IPv6 default route: %w", err)
+	}
+	return r, nil
+}
+
+// lookup sends RTM_GET. Host prefixes are looked up by longest match,
+// shorter prefixes must match exactly.
+func lookup(dst netip.Prefix, zone string, fib int) (Route, error) {
+	if err := validateFIB(fib); err != nil {
+		return Route{}, err
+	}
+	ifindex := 0
+	if zone != "" {
+		idx, err := zoneIndex(zone)
+		if err != nil {
+			return Route{}, err
+		}
+		ifindex = idx
+	}
+
+	m := &routing.Message{
+		Type:  constants.RTM_GET,
+		Flags: constants.RTF_UP,
+	}
+	m.Addrs[constants.RTAX_DST] = routing.IPSockaddrZone(dst.Addr().AsSlice(), ifindex)
+	if dst.IsSingleIP() {
+		m.Flags |= constants.RTF_HOST
+	} else {
+		m.Addrs[constants.RTAX_NETMASK] = routing.MaskSockaddr(net.CIDRMask(dst.Bits(), dst.Addr().BitLen()))
+	}
+	// An empty interface address asks the kernel to report the interface
+	// and its address
+	m.Addrs[constants.RTAX_IFP] = &routing.LinkAddr{}
+
+	reply, err := routing.Request(m, fib)
+	if err != nil {
+		return Route{}, err
+	}
+	r, ok := fromMessage(reply, make(ifNames))
+	if !ok {
+		return Route{}, isyscall.ErrNotFound
+	}
+	return r, nil
+}
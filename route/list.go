@@ -0,0 +1,163 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+)
+
+// DefaultFIB selects the routing table (FIB) of the calling process.
+const DefaultFIB = -1
+
+// List returns the routes of a routing table, like netstat -rn.
+//
+// family selects IPv4 or IPv6 routes; 0 returns both. The routes are those
+// of the process's routing table, or of the table selected with WithFIB.
+// A multipath route
+// is returned once with all its Nexthops. Works without special
+// privileges.
+//
+// Example:
+//
+//	routes, err := route.List(ip.IPv4)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, r := range routes {
+//		gw := r.Gateway.String()
+//		if r.GatewayLink != nil {
+//			gw = r.GatewayLink.String()
+//		}
+//		fmt.Printf("%-20s %-20s %-6s %s\n", r.Dst, gw, r.Flags, r.Interface)
+//	}
+func List(family ip.Family, opts ...Option) ([]Route, error) {
+	af, err := familyAF(family)
+	if err != nil {
+		return nil, err
+	}
+	o, err := buildOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := routing.Dump(af, constants.NET_RT_DUMP, 0, o.fib)
+	if err != nil {
+		return nil, fmt.Errorf("list routes: %w", err)
+	}
+
+	names := make(ifNames)
+	routes := make([]Route, 0, len(msgs))
+	for _, m := range msgs {
+		if r, ok := fromMessage(m, names); ok {
+			routes = append(routes, r)
+		}
+	}
+	return groupNexthops(routes), nil
+}
+
+func familyAF(family ip.Family) (int, error) {
+	switch family {
+	case 0:
+		return 0, nil
+	case ip.IPv4:
+		return constants.AF_INET, nil
+	case ip.IPv6:
+		return constants.AF_INET6, nil
+	}
+	return 0, isyscall.NewValidationError("family", family.String(), "must be IPv4, IPv6 or 0")
+}
+
+// ifNames caches interface names by index while converting messages
+type ifNames map[int]string
+
+func (n ifNames) name(index int) string {
+	if index == 0 {
+		return ""
+	}
+	name, ok := n[index]
+	if !ok {
+		name, _ = ifops.NameByIndex(index)
+		n[index] = name
+	}
+	return name
+}
+
+// fromMessage converts a routing message to a Route. Messages without an
+// IPv4 or IPv6 destination are skipped.
+func fromMessage(m *routing.Message, names ifNames) (Route, bool) {
+	dst := routing.DstPrefix(m)
+	if dst == nil {
+		return Route{}, false
+	}
+	addr, _ := netip.AddrFromSlice(dst.IP)
+	ones, _ := dst.Mask.Size()
+
+	r := Route{
+		Dst:      netip.PrefixFrom(addr.Unmap(), ones),
+		Flags:    RouteFlags(uint32(m.Flags)),
+		MTU:      int(m.Metrics.MTU),
+		Hopcount: int(m.Metrics.Hopcount),
+		Weight:   int(m.Metrics.Weight),
+		Use:      m.Metrics.Pksent,
+	}
+	if m.Metrics.Expire != 0 {
+		r.Expires = time.Unix(int64(m.Metrics.Expire), 0)
+	}
+
+	switch gw := m.Addrs[constants.RTAX_GATEWAY].(type) {
+	case *routing.Inet4Addr, *routing.Inet6Addr:
+		r.Gateway = sockaddrAddr(gw, names)
+	case *routing.LinkAddr:
+		r.GatewayLink = &LinkAddr{Index: gw.Index, Interface: gw.Name, MAC: gw.Addr}
+		if r.GatewayLink.Interface == "" {
+			r.GatewayLink.Interface = names.name(gw.Index)
+		}
+	}
+
+	switch {
+	case r.Flags&FlagBlackhole != 0:
+		r.Kind = KindBlackhole
+	case r.Flags&FlagReject != 0:
+		r.Kind = KindReject
+	case r.Flags&FlagLLInfo != 0:
+		r.Kind = KindLLInfo
+	case r.GatewayLink != nil:
+		r.Kind = KindInterface
+	}
+
+	if ifp, ok := m.Addrs[constants.RTAX_IFP].(*routing.LinkAddr); ok && ifp.Name != "" {
+		r.Interface = ifp.Name
+	} else {
+		r.Interface = names.name(m.Index)
+	}
+	r.IfAddr = sockaddrAddr(m.Addrs[constants.RTAX_IFA], names)
+	return r, true
+}
+
+// sockaddrAddr converts an IPv4 or IPv6 sockaddr, naming the zone of
+// scoped IPv6 addresses after their interface
+func sockaddrAddr(sa routing.Sockaddr, names ifNames) netip.Addr {
+	switch sa := sa.(type) {
+	case *routing.Inet4Addr:
+		return netip.AddrFrom4(sa.IP)
+	case *routing.Inet6Addr:
+		addr := netip.AddrFrom16(sa.IP)
+		if sa.ZoneID != 0 {
+			if name := names.name(int(sa.ZoneID)); name != "" {
+				return addr.WithZone(name)
+			}
+			return addr.WithZone(fmt.Sprintf("%d", sa.ZoneID))
+		}
+		return addr
+	}
+	return netip.Addr{}
+}
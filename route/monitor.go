@@ -0,0 +1,180 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+	"github.com/zombocoder/go-freebsd-ifc/rtmsg"
+)
+
+// EventType is the kind of change a routing socket message reports.
+type EventType int
+
+const (
+	// EventAdd reports an added route (RTM_ADD)
+	EventAdd EventType = iota + 1
+	// EventDelete reports a deleted route (RTM_DELETE)
+	EventDelete
+	// EventChange reports a changed gateway, interface or metric (RTM_CHANGE)
+	EventChange
+	// EventMiss reports a lookup that found no route (RTM_MISS)
+	EventMiss
+	// EventRedirect reports an ICMP redirect to Route.Gateway (RTM_REDIRECT)
+	EventRedirect
+)
+
+var eventTypes = map[EventType]struct {
+	name string
+	rtm  rtmsg.Type
+}{
+	EventAdd:      {"add", rtmsg.TypeAdd},
+	EventDelete:   {"delete", rtmsg.TypeDelete},
+	EventChange:   {"change", rtmsg.TypeChange},
+	EventMiss:     {"miss", rtmsg.TypeMiss},
+	EventRedirect: {"redirect", rtmsg.TypeRedirect},
+}
+
+// String returns the event type as route monitor names it, e.g. "add".
+func (t EventType) String() string {
+	if e, ok := eventTypes[t]; ok {
+		return e.name
+	}
+	return fmt.Sprintf("event%d", int(t))
+}
+
+// Event is a route change reported by the kernel.
+type Event struct {
+	Type  EventType
+	Route Route // Route as carried by the message; a miss only has Dst
+	Pid   int   // Process that made the change, 0 for the kernel itself
+	Err   error // Set on the last event if the socket failed; the channel closes after it
+}
+
+// MonitorFilter selects the events Monitor delivers. The kernel applies
+// the filter, so unwanted messages are never copied to the process.
+type MonitorFilter struct {
+	Types  []EventType // Event types to receive, all if empty
+	Family ip.Family   // IPv4 or IPv6, 0 for both
+}
+
+// Monitor streams route changes, like route monitor, until ctx is done.
+//
+// Only changes to the process's routing table are reported, or to the
+// table selected with WithFIB.
+// The channel is closed when ctx is done. Requests the kernel rejected are
+// not reported. Events caused by this process carry its own pid, so
+// changes made by others (dhclient, rtsold, an operator) can be told apart.
+// Works without special privileges.
+//
+// Example:
+//
+//	events, err := route.Monitor(ctx, route.MonitorFilter{
+//		Types: []route.EventType{route.EventAdd, route.EventDelete, route.EventChange},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for ev := range events {
+//		if ev.Pid != os.Getpid() {
+//			fmt.Println(ev.Type, ev.Route.Dst, ev.Route.Gateway)
+//		}
+//	}
+func Monitor(ctx context.Context, filter MonitorFilter, opts ...Option) (<-chan Event, error) {
+	af, err := familyAF(filter.Family)
+	if err != nil {
+		return nil, err
+	}
+	o, err := buildOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]rtmsg.Type, 0, len(eventTypes))
+	for _, t := range filter.Types {
+		e, ok := eventTypes[t]
+		if !ok {
+			return nil, isyscall.NewValidationError("types", t.String(), "unknown event type")
+		}
+		types = append(types, e.rtm)
+	}
+	if len(types) == 0 {
+		for _, e := range eventTypes {
+			types = append(types, e.rtm)
+		}
+	}
+
+	l, err := routing.Listen(af, o.fib, types)
+	if err != nil {
+		return nil, fmt.Errorf("monitor routes: %w", err)
+	}
+
+	events := make(chan Event)
+	done := make(chan struct{}) // Closed when the reader exits
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		l.Close()
+	}()
+	go func() {
+		defer close(events)
+		defer close(done)
+		names := make(ifNames)
+		for {
+			msgs, err := l.Read()
+			if err != nil {
+				if ctx.Err() == nil && !errors.Is(err, os.ErrClosed) {
+					select {
+					case events <- Event{Err: fmt.Errorf("monitor routes: %w", err)}:
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+			for _, m := range msgs {
+				ev, ok := eventFromMessage(m, names)
+				if !ok {
+					continue
+				}
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// eventFromMessage converts a routing message to an Event. Rejected
+// requests and messages without an IPv4 or IPv6 destination are skipped.
+func eventFromMessage(m *routing.Message, names ifNames) (Event, bool) {
+	if m.Errno != 0 {
+		return Event{}, false
+	}
+	ev := Event{Pid: m.Pid}
+	for t, e := range eventTypes {
+		if e.rtm == m.Type {
+			ev.Type = t
+		}
+	}
+	if ev.Type == 0 {
+		return Event{}, false
+	}
+	r, ok := fromMessage(m, names)
+	if !ok {
+		return Event{}, false
+	}
+	ev.Route = r
+	return ev, true
+}
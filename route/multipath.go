@@ -0,0 +1,199 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+)
+
+// AddNexthop adds a path to the route to dst, turning it into a multipath
+// route, without touching the other nexthops. If there is no route to dst
+// yet, it is created.
+//
+// Requires the kernel's multipath support (net.route.multipath=1, FreeBSD
+// 13 and later) and root privileges.
+//
+// Example:
+//
+//	// Balance the default route over two uplinks, 2:1
+//	dst := netip.MustParsePrefix("0.0.0.0/0")
+//	err := route.AddNexthop(dst, route.Nexthop{Gateway: netip.MustParseAddr("192.0.2.1"), Weight: 20})
+//	err = route.AddNexthop(dst, route.Nexthop{Gateway: netip.MustParseAddr("198.51.100.1"), Weight: 10})
+func AddNexthop(dst netip.Prefix, nh Nexthop, opts ...Option) error {
+	return Add(Route{Dst: dst, Nexthops: []Nexthop{nh}}, append(opts[:len(opts):len(opts)], asNexthop)...)
+}
+
+// DelNexthop removes a path from the route to dst, leaving the other
+// nexthops in place. Deleting the last nexthop deletes the route.
+//
+// Requires root privileges.
+func DelNexthop(dst netip.Prefix, nh Nexthop, opts ...Option) error {
+	return Delete(Route{Dst: dst, Nexthops: []Nexthop{nh}}, opts...)
+}
+
+// modifyNexthops adds or deletes the nexthops of a multipath route one at
+// a time, or a single path route. A failed add removes the nexthops added
+// before it.
+func modifyNexthops(op int, r Route, opts []Option) error {
+	if len(r.Nexthops) == 0 {
+		return modify(op, r, opts)
+	}
+	if r.Gateway.IsValid() || r.Interface != "" {
+		return isyscall.NewValidationError("gateway", r.Gateway.String(), "multipath routes set gateways in Nexthops")
+	}
+	if r.Kind != KindGateway {
+		return isyscall.NewValidationError("kind", r.Kind.String(), "multipath routes have gateways")
+	}
+	for _, nh := range r.Nexthops {
+		if !nh.Gateway.IsValid() {
+			return isyscall.NewValidationError("nexthops", nh.String(), "nexthop needs a gateway")
+		}
+		if nh.Weight < 0 || nh.Weight > constants.RT_MAX_WEIGHT {
+			return isyscall.NewValidationError("weight", fmt.Sprintf("%d", nh.Weight), fmt.Sprintf("must be between 0 and %d", constants.RT_MAX_WEIGHT))
+		}
+	}
+	o, err := buildOptions(opts)
+	if err != nil {
+		return err
+	}
+	if op == constants.RTM_ADD {
+		if err := checkMultipath(); err != nil {
+			return err
+		}
+		// A strict add of a new multipath route must not join an existing
+		// route; the nexthops themselves are added as joins
+		if o.strict && !o.nexthop {
+			existing, err := findRoute(r.Dst.Masked(), 0, o.fib)
+			if err == nil {
+				return &ExistsError{Route: existing}
+			}
+			if !errors.Is(err, isyscall.ErrNotFound) {
+				return err
+			}
+		}
+	}
+
+	var added []Route
+	for _, nh := range r.Nexthops {
+		var res Result
+		nhOpts := append(opts[:len(opts):len(opts)], asNexthop, WithResult(&res))
+		if nh.Weight != 0 {
+			nhOpts = append(nhOpts, WithWeight(nh.Weight))
+		}
+		path := Route{Dst: r.Dst, Gateway: nh.Gateway, Interface: nh.Interface}
+		if err := modify(op, path, nhOpts); err != nil {
+			// Best effort: remove the nexthops this call added, leaving
+			// those that existed before
+			for _, p := range added {
+				modify(constants.RTM_DELETE, p, []Option{WithFIB(o.fib)})
+			}
+			return fmt.Errorf("nexthop %s: %w", nh, err)
+		}
+		if op == constants.RTM_ADD && res.Errno == 0 {
+			added = append(added, path)
+		}
+		if o.result != nil {
+			*o.result = res
+		}
+	}
+	return nil
+}
+
+// replaceNexthops replaces the multipath route cur by the single path
+// route r. The new path joins cur before the others are deleted, so the
+// destination stays reachable.
+func replaceNexthops(cur, r Route, fib int, opts []Option) error {
+	if r.Kind != KindGateway || !r.Gateway.IsValid() {
+		return isyscall.NewValidationError("kind", r.Kind.String(), "multipath routes are replaced by gateway routes")
+	}
+	if err := modify(constants.RTM_ADD, r, append(opts[:len(opts):len(opts)], asNexthop)); err != nil {
+		return err
+	}
+
+	// The new path may already be a nexthop with another weight
+	keep := r.nexthop()
+	keep.Weight = 0
+	var old []Nexthop
+	for _, nh := range cur.Nexthops {
+		if !sameNexthop(keep, nh) {
+			old = append(old, nh)
+		}
+	}
+	if len(old) == 0 {
+		return nil
+	}
+	return modifyNexthops(constants.RTM_DELETE, Route{Dst: cur.Dst, Nexthops: old}, []Option{WithFIB(fib)})
+}
+
+// listRoute returns the route to exactly dst as List reports it, with all
+// nexthops of a multipath route, or a zero Route if there is none
+func listRoute(dst netip.Prefix, fib int) (Route, error) {
+	family := ip.IPv6
+	if dst.Addr().Is4() {
+		family = ip.IPv4
+	}
+	routes, err := List(family, WithFIB(fib))
+	if err != nil {
+		return Route{}, err
+	}
+	for _, r := range routes {
+		if r.Dst == dst && r.Kind != KindLLInfo {
+			return r, nil
+		}
+	}
+	return Route{}, nil
+}
+
+// asNexthop marks an add as one path of a multipath route, which may
+// join an existing route to the destination
+func asNexthop(o *options) {
+	o.nexthop = true
+}
+
+// checkMultipath fails unless the kernel adds routes to an existing
+// destination as nexthops. Otherwise a second gateway would be rejected as
+// an existing route, which Add ignores.
+func checkMultipath() error {
+	v, err := isyscall.SysctlInt(constants.SysctlRouteMultipath)
+	if err != nil || v == 0 {
+		return fmt.Errorf("multipath routing disabled (%s): %w", constants.SysctlRouteMultipath, isyscall.ErrNotSupported)
+	}
+	return nil
+}
+
+// groupNexthops merges consecutive gateway routes to the same destination,
+// which a routing table dump returns for each nexthop of a multipath route.
+// Merged routes leave Gateway, Interface and Weight unset, the shape Add
+// and Delete take.
+func groupNexthops(routes []Route) []Route {
+	grouped := routes[:0]
+	for _, r := range routes {
+		if n := len(grouped); n > 0 && isPath(r) && grouped[n-1].Dst == r.Dst &&
+			(isPath(grouped[n-1]) || len(grouped[n-1].Nexthops) > 0) {
+			prev := &grouped[n-1]
+			if len(prev.Nexthops) == 0 {
+				prev.Nexthops = []Nexthop{prev.nexthop()}
+				prev.Gateway, prev.Interface, prev.Weight = netip.Addr{}, "", 0
+			}
+			prev.Nexthops = append(prev.Nexthops, r.nexthop())
+			continue
+		}
+		grouped = append(grouped, r)
+	}
+	return grouped
+}
+
+func isPath(r Route) bool {
+	return r.Kind == KindGateway && r.Gateway.IsValid()
+}
+
+func (r Route) nexthop() Nexthop {
+	return Nexthop{Gateway: r.Gateway, Interface: r.Interface, Weight: r.Weight}
+}
@@ -0,0 +1,182 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Option configures a route operation.
+type Option func(*options)
+
+type options struct {
+	fib     int
+	flags   RouteFlags
+	inits   uint64
+	metrics routing.Metrics
+	strict  bool
+	nexthop bool // Adding one path of a multipath route
+	result  *Result
+	err     error
+}
+
+// settableFlags may be set with WithFlags
+const settableFlags = FlagFixedMTU | FlagProto1 | FlagProto2 | FlagProto3
+
+// WithFIB makes an operation use routing table fib instead of the
+// process's table, like setfib(1). The number of tables is set by the
+// net.fibs tunable.
+//
+// Example:
+//
+//	// setfib 3 route add -net 10.0.0.0/8 192.0.2.1
+//	_, dst, _ := net.ParseCIDR("10.0.0.0/8")
+//	err := route.AddRoute4(dst, net.ParseIP("192.0.2.1"), "", route.WithFIB(3))
+func WithFIB(fib int) Option {
+	return func(o *options) {
+		o.fib = fib
+	}
+}
+
+// WithStrict turns off the idempotency of Add and Delete: adding a route
+// that exists returns an *ExistsError with the existing route, deleting a
+// missing route returns ErrNotFound. Use it to notice routes that someone
+// else installed with a different gateway.
+//
+// Example:
+//
+//	err := route.Add(r, route.WithStrict())
+//	var exists *route.ExistsError
+//	if errors.As(err, &exists) {
+//		log.Printf("%s already routed via %s", r.Dst, exists.Route.Gateway)
+//	}
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithResult stores the kernel's reply to an Add, Delete or Change in
+// res: the errno the kernel reported, also when an idempotent operation
+// succeeds, and the route as the kernel resolved it. For several nexthops
+// it holds the reply to the last one. Link-layer entries (KindLLInfo)
+// take no options.
+//
+// Example:
+//
+//	var res route.Result
+//	err := route.Add(r, route.WithResult(&res))
+//	if err == nil && res.Errno == syscall.EEXIST {
+//		log.Printf("%s was already routed", r.Dst)
+//	}
+//	fmt.Println(res.Route.Interface)
+func WithResult(res *Result) Option {
+	return func(o *options) {
+		o.result = res
+	}
+}
+
+// WithMTU sets the path MTU of the route (route add -mtu), for example for
+// tunnels that need a lower MTU than their interface. The kernel marks the
+// route with FlagFixedMTU.
+//
+// Example:
+//
+//	err := route.Add(route.Route{Dst: dst, Interface: "gif0", Kind: route.KindInterface},
+//		route.WithMTU(1280))
+func WithMTU(mtu int) Option {
+	return func(o *options) {
+		if mtu < 1 || mtu > 65535 {
+			o.setErr(isyscall.NewValidationError("mtu", fmt.Sprintf("%d", mtu), "must be between 1 and 65535"))
+		}
+		o.inits |= constants.RTV_MTU
+		o.metrics.MTU = uint64(mtu)
+	}
+}
+
+// WithHopcount sets the hop count of the route (route add -hopcount),
+// informational for routing daemons.
+func WithHopcount(hops int) Option {
+	return func(o *options) {
+		if hops < 0 {
+			o.setErr(isyscall.NewValidationError("hopcount", fmt.Sprintf("%d", hops), "must not be negative"))
+		}
+		o.inits |= constants.RTV_HOPCOUNT
+		o.metrics.Hopcount = uint64(hops)
+	}
+}
+
+// WithExpire makes the kernel remove the route after d (route add
+// -expire).
+func WithExpire(d time.Duration) Option {
+	return func(o *options) {
+		if d < time.Second {
+			o.setErr(isyscall.NewValidationError("expire", d.String(), "must be at least one second"))
+		}
+		o.inits |= constants.RTV_EXPIRE
+		o.metrics.Expire = uint64(time.Now().Add(d).Unix())
+	}
+}
+
+// WithWeight sets the weight of the route among the nexthops of a
+// multipath route (route add -weight). The default weight is 1.
+func WithWeight(weight int) Option {
+	return func(o *options) {
+		if weight < 1 || weight > constants.RT_MAX_WEIGHT {
+			o.setErr(isyscall.NewValidationError("weight", fmt.Sprintf("%d", weight),
+				fmt.Sprintf("must be between 1 and %d", constants.RT_MAX_WEIGHT)))
+		}
+		o.inits |= constants.RTV_WEIGHT
+		o.metrics.Weight = uint64(weight)
+	}
+}
+
+// WithFlags sets additional route flags: FlagFixedMTU and the protocol
+// flags FlagProto1, FlagProto2 and FlagProto3, which applications may use
+// to mark their own routes (route add -proto1).
+//
+// Example:
+//
+//	// Mark routes programmed by this agent
+//	err := route.Add(r, route.WithFlags(route.FlagProto1))
+func WithFlags(flags RouteFlags) Option {
+	return func(o *options) {
+		if flags&^settableFlags != 0 {
+			o.setErr(isyscall.NewValidationError("flags", flags.String(), "only FlagFixedMTU and FlagProto1-3 may be set"))
+		}
+		o.flags |= flags
+	}
+}
+
+func (o *options) setErr(err error) {
+	if o.err == nil {
+		o.err = err
+	}
+}
+
+func buildOptions(opts []Option) (options, error) {
+	o := options{fib: DefaultFIB}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.err != nil {
+		return options{}, o.err
+	}
+	if err := validateFIB(o.fib); err != nil {
+		return options{}, err
+	}
+	return o, nil
+}
+
+func validateFIB(fib int) error {
+	if fib < DefaultFIB {
+		return isyscall.NewValidationError("fib", fmt.Sprintf("%d", fib), "must be a table number or DefaultFIB")
+	}
+	return nil
+}
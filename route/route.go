@@ -0,0 +1,324 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"syscall"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/ifops"
+	"github.com/zombocoder/go-freebsd-ifc/internal/routing"
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Add adds a route.
+//
+// The route goes to the process's routing table unless WithFIB selects
+// another one. A route with Nexthops is added as a multipath route, or
+// the nexthops are added to an existing route to r.Dst. If a nexthop
+// cannot be added, the nexthops added before it are removed again.
+//
+// This operation is idempotent - returns nil if the route already exists.
+// With WithStrict it returns an *ExistsError, which matches ErrExists and
+// holds the existing route, also instead of adding Nexthops to an existing
+// route. Requires root privileges.
+//
+// Example:
+//
+//	// IPv6 default route via the router's link-local address
+//	err := route.Add(route.Route{
+//		Dst:     netip.MustParsePrefix("::/0"),
+//		Gateway: netip.MustParseAddr("fe80::1%em0"),
+//	})
+func Add(r Route, opts ...Option) error {
+	if err := modifyNexthops(constants.RTM_ADD, r, opts); err != nil {
+		return fmt.Errorf("add route %s: %w", describe(r), err)
+	}
+	return nil
+}
+
+// Delete deletes a route. For a multipath route, only the nexthops in
+// r.Nexthops are removed.
+//
+// This operation is idempotent - returns nil if the route doesn't exist.
+// With WithStrict it returns ErrNotFound instead, also when a route to
+// r.Dst exists with another gateway. Requires root privileges.
+func Delete(r Route, opts ...Option) error {
+	if err := modifyNexthops(constants.RTM_DELETE, r, opts); err != nil {
+		return fmt.Errorf("delete route %s: %w", describe(r), err)
+	}
+	return nil
+}
+
+// Change replaces the gateway, interface or kind of the route to r.Dst in
+// one kernel operation (RTM_CHANGE), so there is no moment without a route.
+// If there is no route to r.Dst yet, it is added. A multipath route is
+// replaced by adding r as a nexthop and then deleting the other nexthops.
+//
+// Requires root privileges.
+//
+// Example:
+//
+//	// Fail over to the second uplink
+//	err := route.Change(route.Route{
+//		Dst:     netip.MustParsePrefix("0.0.0.0/0"),
+//		Gateway: netip.MustParseAddr("198.51.100.1"),
+//	})
+func Change(r Route, opts ...Option) error {
+	if err := change(r, opts); err != nil {
+		return fmt.Errorf("change route %s: %w", describe(r), err)
+	}
+	return nil
+}
+
+// change sends RTM_CHANGE, or RTM_ADD if there is no route to r.Dst
+func change(r Route, opts []Option) error {
+	o, err := buildOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	// RTM_CHANGE of a multipath route only changes the nexthop with the
+	// same gateway, and fails for another gateway
+	var cur Route
+	if r.Dst.IsValid() && r.Kind != KindLLInfo && len(r.Nexthops) == 0 && checkMultipath() == nil {
+		if cur, err = listRoute(r.Dst.Masked(), o.fib); err != nil {
+			return err
+		}
+		if len(cur.Nexthops) > 0 {
+			return replaceNexthops(cur, r, o.fib, opts)
+		}
+	}
+
+	err = modify(constants.RTM_CHANGE, r, opts)
+	if errors.Is(err, isyscall.ErrNotFound) && !cur.Dst.IsValid() {
+		err = modify(constants.RTM_ADD, r, opts)
+	}
+	return err
+}
+
+// modify sends RTM_ADD, RTM_DELETE or RTM_CHANGE for a route
+func modify(op int, r Route, opts []Option) error {
+	o, err := buildOptions(opts)
+	if err != nil {
+		return err
+	}
+	if len(r.Nexthops) > 0 {
+		return isyscall.NewValidationError("nexthops", r.Dst.String(), "change multipath routes with AddNexthop and DelNexthop")
+	}
+	if !r.Dst.IsValid() {
+		return isyscall.NewValidationError("dst", r.Dst.String(), "invalid destination")
+	}
+	dst := r.Dst.Masked()
+	if r.Gateway.IsValid() && r.Gateway.Is4() != dst.Addr().Is4() {
+		return isyscall.NewValidationError("gateway", r.Gateway.String(), "address family differs from destination")
+	}
+
+	ifindex, err := routeIndex(r)
+	if err != nil {
+		return err
+	}
+
+	spec := routing.RouteSpec{
+		Dst:     prefixIPNet(dst),
+		Ifindex: ifindex,
+		Flags:   int(o.flags),
+		FIB:     o.fib,
+		Inits:   o.inits,
+		Metrics: o.metrics,
+		Strict:  o.strict,
+	}
+
+	switch r.Kind {
+	case KindGateway:
+		if r.Gateway.IsValid() {
+			spec.Gateway = gatewaySockaddr(r.Gateway, ifindex)
+		}
+
+	case KindInterface:
+		if r.Gateway.IsValid() {
+			return isyscall.NewValidationError("gateway", r.Gateway.String(), "interface routes have no gateway")
+		}
+		if ifindex == 0 {
+			return isyscall.NewValidationError("interface", r.Interface, "interface route needs an interface")
+		}
+		spec.Gateway = &routing.LinkAddr{Index: ifindex}
+
+	case KindBlackhole, KindReject:
+		// The kernel wants a gateway; route(8) uses the loopback address
+		gw := r.Gateway
+		if !gw.IsValid() {
+			gw = netip.IPv6Loopback()
+			if dst.Addr().Is4() {
+				gw = netip.AddrFrom4([4]byte{127, 0, 0, 1})
+			}
+		}
+		spec.Gateway = gatewaySockaddr(gw, ifindex)
+		if r.Kind == KindReject {
+			spec.Flags |= constants.RTF_REJECT
+		} else {
+			spec.Flags |= constants.RTF_BLACKHOLE
+		}
+
+	case KindLLInfo:
+		// The kernel keeps link-layer entries in the interface's table, not
+		// in a FIB, and has no metrics or flags for them
+		if o.fib != DefaultFIB || o.flags != 0 || o.inits != 0 || o.strict || o.result != nil {
+			return isyscall.NewValidationError("options", r.Dst.String(), "link-layer entries take no options")
+		}
+		if !dst.IsSingleIP() {
+			return isyscall.NewValidationError("dst", r.Dst.String(), "link-layer entries are host routes")
+		}
+		if ifindex == 0 {
+			return isyscall.NewValidationError("interface", r.Interface, "link-layer entry needs an interface")
+		}
+		sa := routing.IPSockaddrZone(dst.Addr().AsSlice(), ifindex)
+		if op == constants.RTM_DELETE {
+			return routing.DelLL(sa, ifindex)
+		}
+		// Adding replaces an existing entry, which also serves RTM_CHANGE
+		if r.GatewayLink == nil || len(r.GatewayLink.MAC) == 0 {
+			return isyscall.NewValidationError("gatewayLink", "", "link-layer entry needs a MAC address")
+		}
+		return routing.AddLL(sa, r.GatewayLink.MAC, ifindex, 0, 0)
+
+	default:
+		return isyscall.NewValidationError("kind", r.Kind.String(), "unknown route kind")
+	}
+
+	if o.strict && op == constants.RTM_ADD && !o.nexthop {
+		// With multipath routing the kernel adds a route with another
+		// gateway as a nexthop instead of failing
+		existing, err := findRoute(dst, ifindex, o.fib)
+		if err == nil {
+			return &ExistsError{Route: existing}
+		}
+		if !errors.Is(err, isyscall.ErrNotFound) {
+			return err
+		}
+	}
+
+	reply, err := routing.ModifyRoute(op, spec)
+	if o.result != nil && reply != nil {
+		*o.result = Result{Errno: syscall.Errno(reply.Errno)}
+		o.result.Route, _ = fromMessage(reply, make(ifNames))
+	}
+	if o.strict && errors.Is(err, isyscall.ErrExists) {
+		if existing, err := findRoute(dst, ifindex, o.fib); err == nil {
+			return &ExistsError{Route: existing}
+		}
+	}
+	return err
+}
+
+// findRoute returns the route to exactly dst, or ErrNotFound
+func findRoute(dst netip.Prefix, ifindex, fib int) (Route, error) {
+	zone := ""
+	if ifindex != 0 {
+		zone = strconv.Itoa(ifindex)
+	}
+	r, err := lookup(dst, zone, fib)
+	if err != nil {
+		return Route{}, err
+	}
+	// Host routes are looked up by longest match
+	if r.Dst != dst {
+		return Route{}, isyscall.ErrNotFound
+	}
+	return r, nil
+}
+
+func gatewaySockaddr(gw netip.Addr, ifindex int) routing.Sockaddr {
+	return routing.IPSockaddrZone(gw.WithZone("").AsSlice(), ifindex)
+}
+
+// routeIndex resolves the interface of a route from Interface and the
+// gateway's zone
+func routeIndex(r Route) (int, error) {
+	ifindex := 0
+	if r.Interface != "" {
+		idx, err := ifops.IndexByName(r.Interface)
+		if err != nil {
+			return 0, err
+		}
+		ifindex = idx
+	}
+
+	if l := r.GatewayLink; l != nil && (l.Interface != "" || l.Index != 0) {
+		idx := l.Index
+		if l.Interface != "" {
+			var err error
+			if idx, err = ifops.IndexByName(l.Interface); err != nil {
+				return 0, err
+			}
+		}
+		if ifindex != 0 && idx != ifindex {
+			return 0, isyscall.NewValidationError("gatewayLink", l.String(), "interface does not match "+r.Interface)
+		}
+		ifindex = idx
+	}
+
+	if zone := r.Gateway.Zone(); zone != "" {
+		idx, err := zoneIndex(zone)
+		if err != nil {
+			return 0, err
+		}
+		if ifindex != 0 && idx != ifindex {
+			return 0, isyscall.NewValidationError("gateway", r.Gateway.String(), "zone does not match interface "+r.Interface)
+		}
+		ifindex = idx
+	}
+
+	if ifindex == 0 && (r.Gateway.IsLinkLocalUnicast() || r.Dst.Addr().IsLinkLocalUnicast()) {
+		return 0, isyscall.NewValidationError("gateway", r.Gateway.String(), "link-local address needs a zone or interface")
+	}
+	return ifindex, nil
+}
+
+// zoneIndex resolves an IPv6 zone, either an interface name or index
+func zoneIndex(zone string) (int, error) {
+	if idx, err := strconv.Atoi(zone); err == nil {
+		if _, err := ifops.NameByIndex(idx); err != nil {
+			return 0, err
+		}
+		return idx, nil
+	}
+	return ifops.IndexByName(zone)
+}
+
+func prefixIPNet(p netip.Prefix) *net.IPNet {
+	return &net.IPNet{
+		IP:   p.Addr().AsSlice(),
+		Mask: net.CIDRMask(p.Bits(), p.Addr().BitLen()),
+	}
+}
+
+func describe(r Route) string {
+	s := r.Dst.String()
+	if r.Gateway.IsValid() {
+		s += " via " + r.Gateway.String()
+	}
+	for i, nh := range r.Nexthops {
+		if i == 0 {
+			s += " via " + nh.String()
+		} else {
+			s += ", " + nh.String()
+		}
+	}
+	if r.Kind == KindLLInfo && r.GatewayLink != nil {
+		s += " at " + r.GatewayLink.String()
+	}
+	if r.Kind != KindGateway {
+		s += " " + r.Kind.String()
+	}
+	if r.Interface != "" {
+		s += " on " + r.Interface
+	}
+	return s
+}
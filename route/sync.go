@@ -0,0 +1,369 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+	"github.com/zombocoder/go-freebsd-ifc/ip"
+)
+
+// Scope selects the routes Sync manages. Routes outside the scope are
+// never changed.
+//
+// A scope must set Flags, Interface or both: Sync does not manage all
+// static routes of a table, which would delete the default route of
+// dhclient or an operator. Only static routes are in scope; routes the
+// kernel creates for interface addresses and link-layer entries are left
+// alone.
+type Scope struct {
+	Family    ip.Family  // IPv4 or IPv6, 0 for both
+	Flags     RouteFlags // Protocol flags marking managed routes (FlagProto1-3), set on added routes
+	Interface string     // Only routes out of this interface, empty for any
+}
+
+// Plan lists the operations that bring a routing table to the desired
+// state.
+type Plan struct {
+	Add    []Route // Routes to add
+	Change []Route // Desired state of routes whose gateway, interface or kind differ
+	Delete []Route // Routes to delete
+
+	scope Scope
+	fib   int
+	// current holds the routes to the destinations in Change as they are
+	current map[netip.Prefix]Route
+}
+
+// Empty reports whether the routing table is already in the desired
+// state.
+func (p *Plan) Empty() bool {
+	return len(p.Add) == 0 && len(p.Change) == 0 && len(p.Delete) == 0
+}
+
+// String returns the plan one operation per line, e.g.
+// "add 10.0.0.0/8 via 192.0.2.1".
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, op := range []struct {
+		name   string
+		routes []Route
+	}{{"add", p.Add}, {"change", p.Change}, {"delete", p.Delete}} {
+		for _, r := range op.routes {
+			fmt.Fprintf(&b, "%s %s\n", op.name, describe(r))
+		}
+	}
+	return b.String()
+}
+
+// Sync makes the routes in scope match desired: routes missing from the
+// table are added, routes with another gateway, interface or kind are
+// changed, and routes in scope that are not desired are deleted.
+//
+// Changes happen in an order that keeps destinations reachable: routes are
+// added before others are changed and deleted, interface routes are added
+// before the gateway routes that may depend on them and deleted after
+// them, and a route that only changes is replaced atomically. Sync
+// carries on after a failed operation and returns all errors together
+// with the plan.
+//
+// Sync manages the process's routing table, or the table selected with
+// WithFIB; other options are rejected. The scope must set Flags or
+// Interface. Use Flags to mark the routes of
+// an application, so Sync never deletes routes installed by someone else.
+// A desired destination that already
+// has a route outside the scope is left alone and reported as an
+// *ExistsError. Metrics such as the MTU are not compared. A Nexthop weight
+// of 0 matches any weight. Requires root privileges.
+//
+// Example:
+//
+//	scope := route.Scope{Family: ip.IPv4, Flags: route.FlagProto1}
+//	plan, err := route.Sync([]route.Route{
+//		{Dst: netip.MustParsePrefix("10.0.0.0/8"), Gateway: netip.MustParseAddr("192.0.2.1")},
+//		{Dst: netip.MustParsePrefix("172.16.0.0/12"), Gateway: netip.MustParseAddr("192.0.2.1")},
+//	}, scope)
+//	if err != nil {
+//		log.Print(err)
+//	}
+//	fmt.Print(plan)
+func Sync(desired []Route, scope Scope, opts ...Option) (*Plan, error) {
+	p, err := PlanSync(desired, scope, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p, p.Apply()
+}
+
+// PlanSync returns the plan Sync would apply, without changing anything.
+// Works without special privileges.
+func PlanSync(desired []Route, scope Scope, opts ...Option) (*Plan, error) {
+	if err := validateScope(scope); err != nil {
+		return nil, err
+	}
+	o, err := buildOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.flags != 0 || o.inits != 0 || o.strict || o.result != nil {
+		return nil, isyscall.NewValidationError("options", "", "sync only takes WithFIB")
+	}
+	current, err := List(scope.Family, WithFIB(o.fib))
+	if err != nil {
+		return nil, fmt.Errorf("sync routes: %w", err)
+	}
+	p, err := planSync(current, desired, scope)
+	if err != nil {
+		return nil, fmt.Errorf("sync routes: %w", err)
+	}
+	p.fib = o.fib
+	return p, nil
+}
+
+func validateScope(scope Scope) error {
+	if scope.Flags&^(FlagProto1|FlagProto2|FlagProto3) != 0 {
+		return isyscall.NewValidationError("flags", scope.Flags.String(), "only FlagProto1-3 may mark managed routes")
+	}
+	if scope.Flags == 0 && scope.Interface == "" {
+		return isyscall.NewValidationError("scope", "", "set Flags or Interface to select the managed routes")
+	}
+	return nil
+}
+
+// Apply carries out a plan returned by PlanSync. Requires root privileges.
+func (p *Plan) Apply() error {
+	opts := []Option{WithFIB(p.fib)}
+	if p.scope.Flags != 0 {
+		opts = append(opts, WithFlags(p.scope.Flags))
+	}
+
+	// Routes to new destinations must not join foreign routes
+	addOpts := append(opts[:len(opts):len(opts)], WithStrict())
+
+	var errs []error
+	for _, interfaceRoutes := range []bool{true, false} {
+		for _, r := range p.Add {
+			if (r.Kind == KindInterface) == interfaceRoutes {
+				errs = append(errs, Add(r, addOpts...))
+			}
+		}
+	}
+	for _, r := range p.Change {
+		errs = append(errs, p.change(r, opts))
+	}
+	for _, interfaceRoutes := range []bool{false, true} {
+		for _, r := range p.Delete {
+			if (r.Kind == KindInterface) == interfaceRoutes {
+				errs = append(errs, Delete(r, WithFIB(p.fib)))
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("sync routes: %w", err)
+	}
+	return nil
+}
+
+// change replaces a route atomically, or for multipath routes adds the
+// new nexthops before deleting the old ones
+func (p *Plan) change(r Route, opts []Option) error {
+	cur := p.current[r.Dst]
+	if len(r.Nexthops) == 0 && len(cur.Nexthops) == 0 {
+		return Change(r, opts...)
+	}
+
+	have, want := paths(cur), paths(r)
+	var add, del []Nexthop
+	for _, nh := range want {
+		if !containsNexthop(have, nh) {
+			add = append(add, nh)
+		}
+	}
+	for _, nh := range have {
+		if !matchesNexthop(want, nh) {
+			del = append(del, nh)
+		}
+	}
+	if len(add) > 0 {
+		if err := Add(Route{Dst: r.Dst, Nexthops: add}, opts...); err != nil {
+			return err
+		}
+	}
+	if len(del) > 0 {
+		return Delete(Route{Dst: r.Dst, Nexthops: del}, WithFIB(p.fib))
+	}
+	return nil
+}
+
+// planSync compares the current routes with the desired ones
+func planSync(current, desired []Route, scope Scope) (*Plan, error) {
+	want := make(map[netip.Prefix]Route, len(desired))
+	order := make([]netip.Prefix, 0, len(desired))
+	for _, r := range desired {
+		if err := checkDesired(r, scope); err != nil {
+			return nil, err
+		}
+		r.Dst = r.Dst.Masked()
+		if scope.Interface != "" && r.Interface == "" && len(r.Nexthops) == 0 {
+			r.Interface = scope.Interface
+		}
+		if _, dup := want[r.Dst]; dup {
+			return nil, isyscall.NewValidationError("dst", r.Dst.String(), "duplicate destination, use Nexthops for multipath routes")
+		}
+		want[r.Dst] = r
+		order = append(order, r.Dst)
+	}
+
+	p := &Plan{scope: scope, current: make(map[netip.Prefix]Route)}
+	have := make(map[netip.Prefix]bool)
+	for _, cur := range current {
+		if !inScope(cur, scope) {
+			continue
+		}
+		have[cur.Dst] = true
+		r, ok := want[cur.Dst]
+		switch {
+		case !ok:
+			p.Delete = append(p.Delete, managed(cur))
+		case !sameRoute(r, cur):
+			p.Change = append(p.Change, r)
+			p.current[cur.Dst] = cur
+		}
+	}
+	for _, dst := range order {
+		if !have[dst] {
+			p.Add = append(p.Add, want[dst])
+		}
+	}
+	return p, nil
+}
+
+func checkDesired(r Route, scope Scope) error {
+	if !r.Dst.IsValid() {
+		return isyscall.NewValidationError("dst", r.Dst.String(), "invalid destination")
+	}
+	if (scope.Family == ip.IPv4 && !r.Dst.Addr().Is4()) || (scope.Family == ip.IPv6 && !r.Dst.Addr().Is6()) {
+		return isyscall.NewValidationError("dst", r.Dst.String(), "outside of scope family "+scope.Family.String())
+	}
+	if r.Kind == KindLLInfo {
+		return isyscall.NewValidationError("kind", r.Kind.String(), "link-layer entries are not synchronised")
+	}
+	if scope.Interface == "" {
+		return nil
+	}
+	for _, nh := range paths(r) {
+		if nh.Interface != "" && nh.Interface != scope.Interface {
+			return isyscall.NewValidationError("interface", nh.Interface, "outside of scope interface "+scope.Interface)
+		}
+	}
+	return nil
+}
+
+// inScope reports whether Sync manages a route of the table
+func inScope(r Route, scope Scope) bool {
+	if r.Flags&FlagStatic == 0 || r.Flags&(FlagLLInfo|FlagLocal|FlagBroadcast|FlagMulticast|FlagPinned) != 0 {
+		return false
+	}
+	if r.Flags&scope.Flags != scope.Flags {
+		return false
+	}
+	if scope.Interface != "" {
+		for _, nh := range paths(r) {
+			if nh.Interface != scope.Interface {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// managed returns the fields of a listed route that Add and Delete use
+func managed(r Route) Route {
+	if len(r.Nexthops) > 0 {
+		return Route{Dst: r.Dst, Nexthops: r.Nexthops}
+	}
+	return Route{Dst: r.Dst, Gateway: r.Gateway, Interface: r.Interface, Kind: r.Kind, GatewayLink: r.GatewayLink}
+}
+
+// sameRoute reports whether a listed route matches a desired one. Fields
+// the desired route leaves unset match anything.
+func sameRoute(want, have Route) bool {
+	if want.Kind != have.Kind {
+		return false
+	}
+	switch want.Kind {
+	case KindInterface:
+		return want.Interface == "" || want.Interface == have.Interface
+	case KindBlackhole, KindReject:
+		return (!want.Gateway.IsValid() || sameNexthop(want.nexthop(), have.nexthop())) &&
+			(want.Interface == "" || want.Interface == have.Interface)
+	}
+
+	w, h := paths(want), paths(have)
+	if len(w) != len(h) {
+		return false
+	}
+	for _, nh := range w {
+		if !containsNexthop(h, nh) {
+			return false
+		}
+	}
+	return true
+}
+
+// paths returns the nexthops of a route, a single path route as one
+// nexthop
+func paths(r Route) []Nexthop {
+	if len(r.Nexthops) > 0 {
+		return r.Nexthops
+	}
+	return []Nexthop{r.nexthop()}
+}
+
+// containsNexthop reports whether a desired nexthop is among the listed
+// ones
+func containsNexthop(nhs []Nexthop, want Nexthop) bool {
+	for _, nh := range nhs {
+		if sameNexthop(want, nh) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNexthop reports whether a listed nexthop is one of the desired
+// ones
+func matchesNexthop(want []Nexthop, have Nexthop) bool {
+	for _, nh := range want {
+		if sameNexthop(nh, have) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameNexthop compares a desired nexthop with a listed one. The zone of a
+// desired gateway may be an interface name or index; listed gateways are
+// zoned by name.
+func sameNexthop(want, have Nexthop) bool {
+	if want.Gateway.WithZone("") != have.Gateway.WithZone("") {
+		return false
+	}
+	iface := want.Interface
+	if zone := want.Gateway.Zone(); iface == "" && zone != "" {
+		if _, err := strconv.Atoi(zone); err != nil {
+			iface = zone
+		}
+	}
+	if iface != "" && iface != have.Interface {
+		return false
+	}
+	return want.Weight == 0 || want.Weight == have.Weight
+}
@@ -0,0 +1,200 @@
+//go:build freebsd
+// +build freebsd
+
+package route
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Route describes a route.
+//
+// Link-local gateways and destinations are only meaningful on one link:
+// give the interface as the zone of Gateway (fe80::1%em0), in Interface,
+// or both.
+//
+// Add and Delete use Dst, Gateway, Interface and Kind, and GatewayLink for
+// KindLLInfo routes; metrics and flags are set with options such as
+// WithMTU, which KindLLInfo routes reject. List fills in the other fields
+// as well.
+//
+// A multipath route lists its paths in Nexthops and leaves Gateway,
+// Interface and Weight unset. List reports multipath routes the same way,
+// so they can be passed to Delete as they are.
+type Route struct {
+	Dst       netip.Prefix // Destination, 0.0.0.0/0 or ::/0 for the default route
+	Gateway   netip.Addr   // Next hop, may carry a zone
+	Interface string       // Outgoing interface, optional
+	Kind      Kind         // How packets are forwarded, KindGateway by default
+	Nexthops  []Nexthop    // Paths of a multipath route, instead of Gateway and Interface
+
+	GatewayLink *LinkAddr  // Link-layer gateway of interface and LLINFO routes, nil otherwise
+	Flags       RouteFlags // RTF_* flags
+	IfAddr      netip.Addr // Address of the outgoing interface used for this route
+	MTU         int        // Route MTU
+	Hopcount    int        // Hop count set by a routing daemon
+	Weight      int        // Weight among the nexthops of a multipath route
+	Expires     time.Time  // When the route expires, zero if it does not
+	Use         uint64     // Number of packets sent along the route
+}
+
+// Nexthop is one path of a multipath (ECMP) route.
+type Nexthop struct {
+	Gateway   netip.Addr // Next hop, may carry a zone
+	Interface string     // Outgoing interface, optional
+	Weight    int        // Share of the traffic relative to the other nexthops, 0 for the default
+}
+
+// String returns the nexthop like route(8) prints it, e.g.
+// "192.0.2.1 em0 weight 10".
+func (n Nexthop) String() string {
+	s := n.Gateway.String()
+	if n.Interface != "" {
+		s += " " + n.Interface
+	}
+	if n.Weight != 0 {
+		s += fmt.Sprintf(" weight %d", n.Weight)
+	}
+	return s
+}
+
+// Kind selects how a route forwards packets.
+type Kind int
+
+const (
+	// KindGateway forwards to Gateway (route add dst gw)
+	KindGateway Kind = iota
+	// KindInterface sends packets directly out of Interface, for
+	// point-to-point links and on-link prefixes (route add dst -interface ifp)
+	KindInterface
+	// KindBlackhole silently drops packets (route add dst -blackhole)
+	KindBlackhole
+	// KindReject drops packets with an ICMP unreachable (route add dst -reject)
+	KindReject
+	// KindLLInfo is a static link-layer entry for a host: Dst resolves to
+	// GatewayLink.MAC on Interface, like arp -s and ndp -s
+	KindLLInfo
+)
+
+var kindNames = map[Kind]string{
+	KindGateway:   "gateway",
+	KindInterface: "interface",
+	KindBlackhole: "blackhole",
+	KindReject:    "reject",
+	KindLLInfo:    "llinfo",
+}
+
+// String returns the kind as route(8) names it, e.g. "blackhole".
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("kind%d", int(k))
+}
+
+// LinkAddr is a link-layer gateway: the interface a directly connected
+// route leads to, and a hardware address for neighbor entries.
+type LinkAddr struct {
+	Index     int              // Interface index
+	Interface string           // Interface name, may be empty
+	MAC       net.HardwareAddr // Hardware address, nil for interface routes
+}
+
+// String returns the MAC address if set, else "link#N" as netstat(1)
+// prints it.
+func (l *LinkAddr) String() string {
+	if len(l.MAC) > 0 {
+		return l.MAC.String()
+	}
+	return fmt.Sprintf("link#%d", l.Index)
+}
+
+// RouteFlags represents route flags (RTF_*).
+type RouteFlags uint32
+
+const (
+	FlagUp        RouteFlags = 0x1      // Route usable
+	FlagGateway   RouteFlags = 0x2      // Destination is reached through a gateway
+	FlagHost      RouteFlags = 0x4      // Host route
+	FlagReject    RouteFlags = 0x8      // Packets are dropped with an ICMP unreachable
+	FlagDynamic   RouteFlags = 0x10     // Created by a redirect
+	FlagModified  RouteFlags = 0x20     // Modified by a redirect
+	FlagDone      RouteFlags = 0x40     // Message confirmed by the kernel
+	FlagXResolve  RouteFlags = 0x200    // External daemon resolves the name
+	FlagLLInfo    RouteFlags = 0x400    // Link-layer (neighbor) entry
+	FlagStatic    RouteFlags = 0x800    // Added manually
+	FlagBlackhole RouteFlags = 0x1000   // Packets are silently dropped
+	FlagProto2    RouteFlags = 0x4000   // Protocol-specific flag
+	FlagProto1    RouteFlags = 0x8000   // Protocol-specific flag
+	FlagProto3    RouteFlags = 0x40000  // Protocol-specific flag
+	FlagFixedMTU  RouteFlags = 0x80000  // MTU was set explicitly
+	FlagPinned    RouteFlags = 0x100000 // Route cannot be removed by routing daemons
+	FlagLocal     RouteFlags = 0x200000 // Destination is a local address
+	FlagBroadcast RouteFlags = 0x400000 // Destination is a broadcast address
+	FlagMulticast RouteFlags = 0x800000 // Destination is a multicast address
+)
+
+// routeFlagLetters follows the order and letters of netstat(1)
+var routeFlagLetters = []struct {
+	flag   RouteFlags
+	letter byte
+}{
+	{FlagUp, 'U'},
+	{FlagGateway, 'G'},
+	{FlagHost, 'H'},
+	{FlagReject, 'R'},
+	{FlagDynamic, 'D'},
+	{FlagModified, 'M'},
+	{FlagDone, 'd'},
+	{FlagXResolve, 'X'},
+	{FlagStatic, 'S'},
+	{FlagProto1, '1'},
+	{FlagProto2, '2'},
+	{FlagProto3, '3'},
+	{FlagBlackhole, 'B'},
+	{FlagBroadcast, 'b'},
+}
+
+// String returns the flags as netstat -r prints them, e.g. "UGS".
+func (f RouteFlags) String() string {
+	var b []byte
+	for _, fl := range routeFlagLetters {
+		if f&fl.flag != 0 {
+			b = append(b, fl.letter)
+		}
+	}
+	return string(b)
+}
+
+// Result is the kernel's reply to a route operation, see WithResult.
+type Result struct {
+	Errno syscall.Errno // Error the kernel reported, 0 on success
+	Route Route         // The route as the kernel resolved it: gateway, interface and flags
+}
+
+// Re-export common errors from internal package
+var (
+	ErrNotFound     = isyscall.ErrNotFound
+	ErrExists       = isyscall.ErrExists
+	ErrNotSupported = isyscall.ErrNotSupported
+)
+
+// ExistsError is returned by Add with WithStrict when a route to the
+// destination already exists. It matches ErrExists with errors.Is.
+type ExistsError struct {
+	Route Route // The existing route
+}
+
+func (e *ExistsError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrExists, describe(e.Route))
+}
+
+func (e *ExistsError) Unwrap() error {
+	return ErrExists
+}
@@ -0,0 +1,297 @@
+package rtmsg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// amd64 is the layout of the golden messages
+var amd64 = layout{binary.LittleEndian, 8}
+
+func zeros(n int) string {
+	return strings.Repeat("00", n)
+}
+
+var emLink = "36" + "12" + "0200" + "06" + "03" + "06" + "00" + // sockaddr_dl, index 2, IFT_ETHER
+	"656d30" + "001122334455" + zeros(37) + zeros(2) // em0, MAC, padding to SA_SIZE
+
+var goldenMessages = []struct {
+	name    string
+	hex     string
+	msg     Message
+	marshal bool // The message encodes to exactly these bytes
+}{
+	{
+		name: "route add",
+		hex: "c800" + "05" + "01" + "0000" + "0000" + // Length 200, version, RTM_ADD, index
+			"03080000" + "07000000" + "64000000" + "07000000" + // Flags, addrs, pid, seq
+			"00000000" + "00000000" + "0100000000000000" + // Errno, fmask, inits RTV_MTU
+			zeros(8) + "7805000000000000" + zeros(12*8) + // Metrics, MTU 1400
+			"1002" + "0000" + "0a000000" + zeros(8) + // Dst 10.0.0.0
+			"1002" + "0000" + "c0000201" + zeros(8) + // Gateway 192.0.2.1
+			"1002" + "0000" + "ff000000" + zeros(8), // Netmask 255.0.0.0
+		msg: &RouteMessage{
+			Type:    TypeAdd,
+			Flags:   0x803,
+			Pid:     100,
+			Seq:     7,
+			Inits:   1,
+			Metrics: Metrics{MTU: 1400},
+			Addrs: [NumAddrs]Sockaddr{
+				AddrDst:     &Inet4Addr{IP: [4]byte{10, 0, 0, 0}},
+				AddrGateway: &Inet4Addr{IP: [4]byte{192, 0, 2, 1}},
+				AddrNetmask: &Inet4Addr{IP: [4]byte{255, 0, 0, 0}},
+			},
+		},
+		marshal: true,
+	},
+	{
+		name: "route get reply",
+		hex: "0001" + "05" + "04" + "0200" + "0000" + // Length 256, RTM_GET, index 2
+			"41000000" + "15000000" + zeros(16) + zeros(120) + // Flags, addrs dst|netmask|ifp
+			"1c1c" + "0000" + "00000000" + "fe800002000000000000000000000000" + "00000000" + zeros(4) + // fe80:: with embedded scope 2
+			"1000" + "0000" + "00000000" + "ffffffffffffffff" + // Truncated /64 mask without family
+			emLink,
+		msg: &RouteMessage{
+			Type:  TypeGet,
+			Index: 2,
+			Flags: 0x41,
+			Addrs: [NumAddrs]Sockaddr{
+				AddrDst:     &Inet6Addr{IP: [16]byte{0xfe, 0x80}, ZoneID: 2},
+				AddrNetmask: &Inet6Addr{IP: [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+				AddrIfp:     &LinkAddr{Index: 2, Type: 6, Name: "em0", Addr: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}},
+			},
+		},
+	},
+	{
+		name: "interface info",
+		hex: "e000" + "05" + "0e" + "10000000" + "43800000" + "0200" + "0000" + // Length 224, RTM_IFINFO, addrs ifp, flags, index 2
+			"06" + "00" + "06" + "0e" + "02" + "00" + "9800" + "dc050000" + "00000000" + // Type, link state up, datalen, MTU 1500
+			"00ca9a3b00000000" + "0a00000000000000" + zeros(12*8) + // Baudrate 1 Gbit/s, 10 packets in
+			"6400000000000000" + "00f1536500000000" + "20a1070000000000" + // Epoch, last change
+			emLink,
+		msg: &InterfaceMessage{
+			Type:  TypeIfInfo,
+			Index: 2,
+			Flags: 0x8043,
+			Data: InterfaceData{
+				Type:       6,
+				AddrLen:    6,
+				HdrLen:     14,
+				LinkState:  2,
+				MTU:        1500,
+				Baudrate:   1000000000,
+				IPackets:   10,
+				Epoch:      100,
+				LastChange: time.Unix(1700000000, 500000000),
+			},
+			Addrs: [NumAddrs]Sockaddr{
+				AddrIfp: &LinkAddr{Index: 2, Type: 6, Name: "em0", Addr: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}},
+			},
+		},
+		marshal: true,
+	},
+	{
+		name: "new address",
+		hex: "4400" + "05" + "0c" + "a4000000" + "43800000" + "0200" + "0000" + "00000000" + // Length 68, RTM_NEWADDR, addrs netmask|ifa|brd
+			"1002" + "0000" + "ffffff00" + zeros(8) + // Netmask, directly after the 20 byte header
+			"1002" + "0000" + "c0000201" + zeros(8) + // Address 192.0.2.1
+			"1002" + "0000" + "c00002ff" + zeros(8), // Broadcast
+		msg: &InterfaceAddrMessage{
+			Type:  TypeNewAddr,
+			Index: 2,
+			Flags: 0x8043,
+			Addrs: [NumAddrs]Sockaddr{
+				AddrNetmask: &Inet4Addr{IP: [4]byte{255, 255, 255, 0}},
+				AddrIfa:     &Inet4Addr{IP: [4]byte{192, 0, 2, 1}},
+				AddrBrd:     &Inet4Addr{IP: [4]byte{192, 0, 2, 255}},
+			},
+		},
+		marshal: true,
+	},
+	{
+		name: "new multicast address",
+		hex: "5800" + "05" + "0f" + "22000000" + "00000000" + "0200" + "0000" + // Length 88, RTM_NEWMADDR, addrs gateway|ifa
+			"36" + "12" + "0200" + "06" + "00" + "06" + "00" + "01005e000001" + zeros(40) + zeros(2) + // Group MAC
+			"1002" + "0000" + "e0000001" + zeros(8), // 224.0.0.1
+		msg: &InterfaceMulticastAddrMessage{
+			Type:  TypeNewMAddr,
+			Index: 2,
+			Addrs: [NumAddrs]Sockaddr{
+				AddrGateway: &LinkAddr{Index: 2, Type: 6, Addr: []byte{0x01, 0x00, 0x5e, 0x00, 0x00, 0x01}},
+				AddrIfa:     &Inet4Addr{IP: [4]byte{224, 0, 0, 1}},
+			},
+		},
+		marshal: true,
+	},
+	{
+		name: "interface announcement",
+		hex:  "1800" + "05" + "11" + "0300" + "65706169723061" + zeros(9) + "0000", // Length 24, RTM_IFANNOUNCE, epair0a arrived
+		msg: &InterfaceAnnounceMessage{
+			Type:  TypeIfAnnounce,
+			Index: 3,
+			Name:  "epair0a",
+			What:  AnnounceArrival,
+		},
+		marshal: true,
+	},
+}
+
+// TestGolden tests decoding and encoding of messages captured in the
+// amd64 layout
+func TestGolden(t *testing.T) {
+	var all []byte
+	for _, tt := range goldenMessages {
+		b, err := hex.DecodeString(tt.hex)
+		if err != nil {
+			t.Fatalf("%s: invalid fixture: %v", tt.name, err)
+		}
+		all = append(all, b...)
+
+		msgs, err := amd64.parseMessages(b)
+		if err != nil {
+			t.Errorf("%s: parseMessages() failed: %v", tt.name, err)
+			continue
+		}
+		if len(msgs) != 1 || !reflect.DeepEqual(msgs[0], tt.msg) {
+			t.Errorf("%s: parseMessages() =\n%+v\nwant\n%+v", tt.name, msgs, tt.msg)
+		}
+
+		if !tt.marshal {
+			continue
+		}
+		got, err := amd64.marshal(tt.msg)
+		if err != nil {
+			t.Errorf("%s: marshal() failed: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(got, b) {
+			t.Errorf("%s: marshal() mismatch:\n got %x\nwant %x", tt.name, got, b)
+		}
+	}
+
+	msgs, err := amd64.parseMessages(all)
+	if err != nil || len(msgs) != len(goldenMessages) {
+		t.Errorf("parseMessages() of all fixtures returned %d messages, %v", len(msgs), err)
+	}
+}
+
+// TestLayout32 tests the layout of 32-bit platforms, where u_long fields
+// and sockaddr alignment are 4 bytes
+func TestLayout32(t *testing.T) {
+	i386 := layout{binary.LittleEndian, 4}
+	m := &RouteMessage{
+		Type:    TypeAdd,
+		Inits:   1,
+		Metrics: Metrics{MTU: 1400},
+		Addrs: [NumAddrs]Sockaddr{
+			AddrDst:     &Inet4Addr{IP: [4]byte{10, 0, 0, 0}},
+			AddrGateway: &LinkAddr{Index: 1},
+		},
+	}
+	b, err := i386.marshal(m)
+	if err != nil {
+		t.Fatalf("marshal() failed: %v", err)
+	}
+	if len(b) != 92+16+56 {
+		t.Errorf("Length = %d, want %d", len(b), 92+16+56)
+	}
+	if got := binary.LittleEndian.Uint32(b[40:]); got != 1400 {
+		t.Errorf("rmx_mtu = %d, want 1400", got)
+	}
+	if b[92] != sizeofSockaddrIn || b[108] != sizeofSockaddrDL {
+		t.Errorf("Sockaddrs at %d and %d, lengths %d and %d", 92, 108, b[92], b[108])
+	}
+
+	msgs, err := i386.parseMessages(b)
+	if err != nil || len(msgs) != 1 || !reflect.DeepEqual(msgs[0], m) {
+		t.Errorf("parseMessages() = %+v, %v", msgs, err)
+	}
+}
+
+// TestMalformed tests rejection of truncated messages
+func TestMalformed(t *testing.T) {
+	route, _ := hex.DecodeString(goldenMessages[0].hex)
+
+	tests := map[string][]byte{
+		"length beyond buffer": route[:len(route)-1],
+		"length too small":     {0x02, 0x00, Version, byte(TypeAdd)},
+		"short header":         append([]byte{0x10, 0x00, Version, byte(TypeAdd)}, make([]byte, 12)...),
+		"missing address":      append(append([]byte{}, route[:2]...), route[2:152]...),
+	}
+	binary.LittleEndian.PutUint16(tests["missing address"], 152)
+
+	for name, b := range tests {
+		if _, err := amd64.parseMessages(b); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: expected ErrMalformed, got %v", name, err)
+		}
+	}
+
+	// Unknown types and versions are skipped
+	skipped := []byte{0x04, 0x00, Version, 0x09, 0x04, 0x00, Version + 1, byte(TypeAdd)}
+	if msgs, err := amd64.parseMessages(skipped); err != nil || len(msgs) != 0 {
+		t.Errorf("parseMessages() = %v, %v, want nothing", msgs, err)
+	}
+}
+
+// TestMarshalInvalid tests messages that cannot be encoded
+func TestMarshalInvalid(t *testing.T) {
+	tests := map[string]Message{
+		"header of type":    &RouteMessage{Type: TypeIfInfo},
+		"unknown type":      &InterfaceAddrMessage{Type: 0x9},
+		"long name":         &InterfaceAnnounceMessage{Type: TypeIfAnnounce, Name: strings.Repeat("x", 17)},
+		"nil sockaddr":      &RouteMessage{Type: TypeAdd, Addrs: [NumAddrs]Sockaddr{AddrDst: (*Inet4Addr)(nil)}},
+		"long link addr":    &RouteMessage{Type: TypeAdd, Addrs: [NumAddrs]Sockaddr{AddrGateway: &LinkAddr{Addr: make([]byte, 250)}}},
+		"long raw sockaddr": &RouteMessage{Type: TypeAdd, Addrs: [NumAddrs]Sockaddr{AddrDst: &RawAddr{Data: make([]byte, 254)}}},
+	}
+	for name, m := range tests {
+		if _, err := m.Marshal(); err == nil {
+			t.Errorf("%s: Marshal() should fail", name)
+		}
+	}
+}
+
+// TestTypeString tests message type names
+func TestTypeString(t *testing.T) {
+	if got := TypeIfAnnounce.String(); got != "RTM_IFANNOUNCE" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Type(0x9).String(); got != "RTM_0x9" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// FuzzParseMessages tests that decoding never panics and that decoded
+// messages encode to bytes that decode to the same messages
+func FuzzParseMessages(f *testing.F) {
+	for _, tt := range goldenMessages {
+		b, _ := hex.DecodeString(tt.hex)
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		msgs, err := amd64.parseMessages(b)
+		if err != nil {
+			return
+		}
+		for _, m := range msgs {
+			enc, err := amd64.marshal(m)
+			if err != nil {
+				t.Fatalf("marshal(%+v) failed: %v", m, err)
+			}
+			again, err := amd64.parseMessages(enc)
+			if err != nil || len(again) != 1 {
+				t.Fatalf("parseMessages(marshal(%+v)) = %v, %v", m, again, err)
+			}
+			if !reflect.DeepEqual(again[0], m) {
+				t.Fatalf("Round trip mismatch:\n got %+v\nwant %+v", again[0], m)
+			}
+		}
+	})
+}
@@ -0,0 +1,64 @@
+//go:build freebsd
+// +build freebsd
+
+package rtmsg
+
+import (
+	"testing"
+
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+)
+
+// TestConstants checks the values and layout of this package against the
+// system headers
+func TestConstants(t *testing.T) {
+	values := []struct {
+		name      string
+		got, want int
+	}{
+		{"RTM_VERSION", Version, constants.RTM_VERSION},
+		{"RTM_ADD", int(TypeAdd), constants.RTM_ADD},
+		{"RTM_DELETE", int(TypeDelete), constants.RTM_DELETE},
+		{"RTM_CHANGE", int(TypeChange), constants.RTM_CHANGE},
+		{"RTM_GET", int(TypeGet), constants.RTM_GET},
+		{"RTM_LOSING", int(TypeLosing), constants.RTM_LOSING},
+		{"RTM_REDIRECT", int(TypeRedirect), constants.RTM_REDIRECT},
+		{"RTM_MISS", int(TypeMiss), constants.RTM_MISS},
+		{"RTM_LOCK", int(TypeLock), constants.RTM_LOCK},
+		{"RTM_RESOLVE", int(TypeResolve), constants.RTM_RESOLVE},
+		{"RTM_NEWADDR", int(TypeNewAddr), constants.RTM_NEWADDR},
+		{"RTM_DELADDR", int(TypeDelAddr), constants.RTM_DELADDR},
+		{"RTM_IFINFO", int(TypeIfInfo), constants.RTM_IFINFO},
+		{"RTM_NEWMADDR", int(TypeNewMAddr), constants.RTM_NEWMADDR},
+		{"RTM_DELMADDR", int(TypeDelMAddr), constants.RTM_DELMADDR},
+		{"RTM_IFANNOUNCE", int(TypeIfAnnounce), constants.RTM_IFANNOUNCE},
+		{"RTM_IEEE80211", int(TypeIEEE80211), constants.RTM_IEEE80211},
+		{"RTAX_DST", AddrDst, constants.RTAX_DST},
+		{"RTAX_GATEWAY", AddrGateway, constants.RTAX_GATEWAY},
+		{"RTAX_NETMASK", AddrNetmask, constants.RTAX_NETMASK},
+		{"RTAX_GENMASK", AddrGenmask, constants.RTAX_GENMASK},
+		{"RTAX_IFP", AddrIfp, constants.RTAX_IFP},
+		{"RTAX_IFA", AddrIfa, constants.RTAX_IFA},
+		{"RTAX_AUTHOR", AddrAuthor, constants.RTAX_AUTHOR},
+		{"RTAX_BRD", AddrBrd, constants.RTAX_BRD},
+		{"RTAX_MAX", NumAddrs, constants.RTAX_MAX},
+		{"AF_INET", AFInet, constants.AF_INET},
+		{"AF_INET6", AFInet6, constants.AF_INET6},
+		{"AF_LINK", AFLink, constants.AF_LINK},
+		{"IFAN_ARRIVAL", AnnounceArrival, constants.IFAN_ARRIVAL},
+		{"IFAN_DEPARTURE", AnnounceDeparture, constants.IFAN_DEPARTURE},
+		{"sizeof(struct rt_msghdr)", native.sizeofRtMsghdr(), constants.SizeofRtMsghdr},
+		{"sizeof(struct if_msghdr)", sizeofIfMsghdr, constants.SizeofIfMsghdr},
+		{"sizeof(struct ifa_msghdr)", sizeofIfaMsghdr, constants.SizeofIfaMsghdr},
+		{"sizeof(struct ifma_msghdr)", sizeofIfmaMsghdr, constants.SizeofIfmaMsghdr},
+		{"sizeof(struct if_announcemsghdr)", sizeofIfAnnounceMsghdr, constants.SizeofIfAnnounceMsghdr},
+		{"sizeof(struct sockaddr_in)", sizeofSockaddrIn, constants.SizeofSockaddrIn},
+		{"sizeof(struct sockaddr_in6)", sizeofSockaddrIn6, constants.SizeofSockaddrIn6},
+		{"sizeof(struct sockaddr_dl)", sizeofSockaddrDL, constants.SizeofSockaddrDL},
+	}
+	for _, v := range values {
+		if v.got != v.want {
+			t.Errorf("%s = %d, want %d", v.name, v.got, v.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Package rtmsg encodes and decodes FreeBSD routing socket messages.
+
+A routing socket (route(4)) carries route requests and replies as well as
+notifications about routes, interfaces and addresses. This package turns
+the raw bytes into typed messages and back, for programs that open their
+own routing socket or read route dumps from sysctl(3).
+
+# Parsing
+
+ParseMessages decodes a buffer holding one or more messages, as returned
+by a read from a routing socket or a NET_RT_DUMP / NET_RT_IFLIST sysctl:
+
+	n, err := syscall.Read(fd, buf)
+	msgs, err := rtmsg.ParseMessages(buf[:n])
+	for _, m := range msgs {
+		switch m := m.(type) {
+		case *rtmsg.RouteMessage:
+			fmt.Println(m.Type, m.Addrs[rtmsg.AddrDst], m.Addrs[rtmsg.AddrGateway])
+		case *rtmsg.InterfaceAnnounceMessage:
+			fmt.Println(m.Name, m.What == rtmsg.AnnounceArrival)
+		}
+	}
+
+Messages of unknown types or versions are skipped, so a newer kernel does
+not break older programs. Truncated messages and sockaddrs return
+ErrMalformed.
+
+# Encoding
+
+Marshal encodes a message in the kernel's wire format:
+
+	m := &rtmsg.RouteMessage{
+		Type:  rtmsg.TypeGet,
+		Flags: 0x1, // RTF_UP
+		Seq:   1,
+	}
+	m.Addrs[rtmsg.AddrDst] = &rtmsg.Inet4Addr{IP: [4]byte{192, 0, 2, 1}}
+	b, err := m.Marshal()
+	_, err = syscall.Write(fd, b)
+
+# Addresses
+
+Addrs holds the sockaddrs of a message indexed by Addr*, nil where the
+message has none. Netmasks are decoded with the family of the destination.
+The zone of link-local IPv6 addresses is reported in Inet6Addr.ZoneID,
+whether or not the kernel embedded it in the address.
+
+# Portability
+
+The package is pure Go and builds on every platform, so code handling
+routing messages can be tested anywhere. Messages use the host's byte
+order and long size, like the kernel. The extended headers of
+NET_RT_IFLISTL are not supported.
+*/
+package rtmsg
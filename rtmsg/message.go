@@ -0,0 +1,395 @@
+package rtmsg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+// layout describes the platform's message layout. Structures are in host
+// byte order, and routing messages align u_long fields and sockaddrs to
+// sizeof(long).
+type layout struct {
+	order binary.ByteOrder
+	long  int
+}
+
+// native is the layout of the running system; long is the pointer size on
+// every FreeBSD platform
+var native = layout{binary.NativeEndian, int(unsafe.Sizeof(uintptr(0)))}
+
+// Sizes of the fixed message headers. struct rt_msghdr is 32 bytes of
+// fixed fields, rtm_inits and the 14 u_long fields of struct rt_metrics.
+const (
+	sizeofIfMsghdr         = 16 + sizeofIfData
+	sizeofIfData           = 152
+	sizeofIfaMsghdr        = 20
+	sizeofIfmaMsghdr       = 16
+	sizeofIfAnnounceMsghdr = 24
+	sizeofRtMetrics        = 14 // u_long fields, the last two are filler
+
+	ifNameSize = 16 // IFNAMSIZ
+)
+
+func (l layout) sizeofRtMsghdr() int {
+	return 32 + (1+sizeofRtMetrics)*l.long
+}
+
+// header kinds, by the struct a message type starts with
+const (
+	headerUnknown = iota
+	headerRoute
+	headerInterface
+	headerInterfaceAddr
+	headerMulticastAddr
+	headerAnnounce
+)
+
+func headerOf(t Type) int {
+	switch t {
+	case TypeAdd, TypeDelete, TypeChange, TypeGet, TypeLosing, TypeRedirect, TypeMiss, TypeLock, TypeResolve:
+		return headerRoute
+	case TypeIfInfo:
+		return headerInterface
+	case TypeNewAddr, TypeDelAddr:
+		return headerInterfaceAddr
+	case TypeNewMAddr, TypeDelMAddr:
+		return headerMulticastAddr
+	case TypeIfAnnounce, TypeIEEE80211:
+		return headerAnnounce
+	}
+	return headerUnknown
+}
+
+func (l layout) headerLen(kind int) int {
+	switch kind {
+	case headerRoute:
+		return l.sizeofRtMsghdr()
+	case headerInterface:
+		return sizeofIfMsghdr
+	case headerInterfaceAddr:
+		return sizeofIfaMsghdr
+	case headerMulticastAddr:
+		return sizeofIfmaMsghdr
+	case headerAnnounce:
+		return sizeofIfAnnounceMsghdr
+	}
+	return 0
+}
+
+// ParseMessages decodes a buffer of routing messages, as read from a
+// routing socket or returned by the NET_RT_DUMP and NET_RT_IFLIST
+// sysctls. Messages of another version or of unknown types are skipped.
+//
+// Example:
+//
+//	n, err := syscall.Read(fd, buf)
+//	msgs, err := rtmsg.ParseMessages(buf[:n])
+//	for _, m := range msgs {
+//		if rm, ok := m.(*rtmsg.RouteMessage); ok {
+//			fmt.Println(rm.Type, rm.Addrs[rtmsg.AddrDst])
+//		}
+//	}
+func ParseMessages(b []byte) ([]Message, error) {
+	return native.parseMessages(b)
+}
+
+func (l layout) parseMessages(b []byte) ([]Message, error) {
+	var msgs []Message
+	for len(b) >= 4 {
+		msglen := int(l.order.Uint16(b[0:]))
+		if msglen < 4 || msglen > len(b) {
+			return nil, fmt.Errorf("%w: length %d of %d bytes", ErrMalformed, msglen, len(b))
+		}
+		if b[2] == Version {
+			m, err := l.parseMessage(b[:msglen])
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				msgs = append(msgs, m)
+			}
+		}
+		b = b[msglen:]
+	}
+	return msgs, nil
+}
+
+// parseMessage decodes one message; it returns nil for unknown types
+func (l layout) parseMessage(b []byte) (Message, error) {
+	typ := Type(b[3])
+	kind := headerOf(typ)
+	if kind == headerUnknown {
+		return nil, nil
+	}
+	hdrlen := l.headerLen(kind)
+	if len(b) < hdrlen {
+		return nil, fmt.Errorf("%w: %s of %d bytes", ErrMalformed, typ, len(b))
+	}
+
+	o := l.order
+	switch kind {
+	case headerRoute:
+		m := &RouteMessage{
+			Type:  typ,
+			Index: int(o.Uint16(b[4:])),
+			Flags: int(int32(o.Uint32(b[8:]))),
+			Pid:   int(int32(o.Uint32(b[16:]))),
+			Seq:   int(int32(o.Uint32(b[20:]))),
+			Errno: int(int32(o.Uint32(b[24:]))),
+			Fmask: int(int32(o.Uint32(b[28:]))),
+			Inits: l.getLong(b[32:]),
+		}
+		m.Metrics = l.parseMetrics(b[32+l.long:])
+		addrs, err := l.parseAddrs(int(o.Uint32(b[12:])), b[hdrlen:])
+		m.Addrs = addrs
+		return m, err
+
+	case headerInterface:
+		m := &InterfaceMessage{
+			Type:  typ,
+			Flags: int(int32(o.Uint32(b[8:]))),
+			Index: int(o.Uint16(b[12:])),
+			Data:  l.parseIfData(b[16:]),
+		}
+		addrs, err := l.parseAddrs(int(o.Uint32(b[4:])), b[hdrlen:])
+		m.Addrs = addrs
+		return m, err
+
+	case headerInterfaceAddr:
+		m := &InterfaceAddrMessage{
+			Type:   typ,
+			Flags:  int(int32(o.Uint32(b[8:]))),
+			Index:  int(o.Uint16(b[12:])),
+			Metric: int(int32(o.Uint32(b[16:]))),
+		}
+		addrs, err := l.parseAddrs(int(o.Uint32(b[4:])), b[hdrlen:])
+		m.Addrs = addrs
+		return m, err
+
+	case headerMulticastAddr:
+		m := &InterfaceMulticastAddrMessage{
+			Type:  typ,
+			Flags: int(int32(o.Uint32(b[8:]))),
+			Index: int(o.Uint16(b[12:])),
+		}
+		addrs, err := l.parseAddrs(int(o.Uint32(b[4:])), b[hdrlen:])
+		m.Addrs = addrs
+		return m, err
+	}
+
+	name := b[6 : 6+ifNameSize]
+	for i, c := range name {
+		if c == 0 {
+			name = name[:i]
+			break
+		}
+	}
+	m := &InterfaceAnnounceMessage{
+		Type:  typ,
+		Index: int(o.Uint16(b[4:])),
+		Name:  string(name),
+		What:  int(o.Uint16(b[22:])),
+	}
+	if len(b) > hdrlen {
+		m.Data = append([]byte(nil), b[hdrlen:]...)
+	}
+	return m, nil
+}
+
+func (l layout) parseMetrics(b []byte) Metrics {
+	var v [sizeofRtMetrics]uint64
+	for i := range v {
+		v[i] = l.getLong(b[i*l.long:])
+	}
+	return Metrics{
+		Locks: v[0], MTU: v[1], Hopcount: v[2], Expire: v[3], Recvpipe: v[4], Sendpipe: v[5],
+		Ssthresh: v[6], RTT: v[7], RTTVar: v[8], Pksent: v[9], Weight: v[10], Nhidx: v[11],
+	}
+}
+
+func (l layout) putMetrics(b []byte, m *Metrics) {
+	v := [sizeofRtMetrics]uint64{
+		m.Locks, m.MTU, m.Hopcount, m.Expire, m.Recvpipe, m.Sendpipe,
+		m.Ssthresh, m.RTT, m.RTTVar, m.Pksent, m.Weight, m.Nhidx,
+	}
+	for i := range v {
+		l.putLong(b[i*l.long:], v[i])
+	}
+}
+
+// if_data counters, in struct order from ifi_baudrate
+func (d *InterfaceData) counters() []*uint64 {
+	return []*uint64{
+		&d.Baudrate, &d.IPackets, &d.IErrors, &d.OPackets, &d.OErrors, &d.Collisions,
+		&d.IBytes, &d.OBytes, &d.IMcasts, &d.OMcasts, &d.IQDrops, &d.OQDrops,
+		&d.NoProto, &d.HWAssist,
+	}
+}
+
+func (l layout) parseIfData(b []byte) InterfaceData {
+	o := l.order
+	d := InterfaceData{
+		Type:      int(b[0]),
+		Physical:  int(b[1]),
+		AddrLen:   int(b[2]),
+		HdrLen:    int(b[3]),
+		LinkState: int(b[4]),
+		VHID:      int(b[5]),
+		MTU:       int(o.Uint32(b[8:])),
+		Metric:    int(o.Uint32(b[12:])),
+		Epoch:     int64(o.Uint64(b[128:])),
+	}
+	for i, c := range d.counters() {
+		*c = o.Uint64(b[16+8*i:])
+	}
+
+	// ifi_lastchange is a struct timeval of a time_t and a long
+	sec, usec := int64(l.getLong(b[136:])), int64(l.getLong(b[136+l.long:]))
+	if l.long == 4 {
+		sec, usec = int64(int32(sec)), int64(int32(usec))
+	}
+	if t := time.Unix(sec+usec/1e6, usec%1e6*1e3); (sec != 0 || usec != 0) && !t.IsZero() {
+		d.LastChange = t
+	}
+	return d
+}
+
+func (l layout) putIfData(b []byte, d *InterfaceData) {
+	o := l.order
+	b[0], b[1], b[2], b[3] = byte(d.Type), byte(d.Physical), byte(d.AddrLen), byte(d.HdrLen)
+	b[4], b[5] = byte(d.LinkState), byte(d.VHID)
+	o.PutUint16(b[6:], sizeofIfData)
+	o.PutUint32(b[8:], uint32(d.MTU))
+	o.PutUint32(b[12:], uint32(d.Metric))
+	for i, c := range d.counters() {
+		o.PutUint64(b[16+8*i:], *c)
+	}
+	o.PutUint64(b[128:], uint64(d.Epoch))
+	if !d.LastChange.IsZero() {
+		l.putLong(b[136:], uint64(d.LastChange.Unix()))
+		l.putLong(b[136+l.long:], uint64(d.LastChange.Nanosecond()/1e3))
+	}
+}
+
+// Marshal encodes the message in the kernel's wire format
+func (m *RouteMessage) Marshal() ([]byte, error) { return native.marshal(m) }
+
+// Marshal encodes the message in the kernel's wire format
+func (m *InterfaceMessage) Marshal() ([]byte, error) { return native.marshal(m) }
+
+// Marshal encodes the message in the kernel's wire format
+func (m *InterfaceAddrMessage) Marshal() ([]byte, error) { return native.marshal(m) }
+
+// Marshal encodes the message in the kernel's wire format
+func (m *InterfaceMulticastAddrMessage) Marshal() ([]byte, error) { return native.marshal(m) }
+
+// Marshal encodes the message in the kernel's wire format
+func (m *InterfaceAnnounceMessage) Marshal() ([]byte, error) { return native.marshal(m) }
+
+func (l layout) marshal(msg Message) ([]byte, error) {
+	typ := msg.MessageType()
+	kind := headerOf(typ)
+	b := make([]byte, l.headerLen(kind))
+	o := l.order
+
+	var addrs int
+	var err error
+	switch m := msg.(type) {
+	case *RouteMessage:
+		if kind != headerRoute {
+			break
+		}
+		if b, addrs, err = l.appendAddrs(b, &m.Addrs); err != nil {
+			return nil, err
+		}
+		o.PutUint16(b[4:], uint16(m.Index))
+		o.PutUint32(b[8:], uint32(m.Flags))
+		o.PutUint32(b[12:], uint32(addrs))
+		o.PutUint32(b[16:], uint32(m.Pid))
+		o.PutUint32(b[20:], uint32(m.Seq))
+		o.PutUint32(b[24:], uint32(m.Errno))
+		o.PutUint32(b[28:], uint32(m.Fmask))
+		l.putLong(b[32:], m.Inits)
+		l.putMetrics(b[32+l.long:], &m.Metrics)
+		return l.finish(b, typ)
+
+	case *InterfaceMessage:
+		if kind != headerInterface {
+			break
+		}
+		if b, addrs, err = l.appendAddrs(b, &m.Addrs); err != nil {
+			return nil, err
+		}
+		o.PutUint32(b[4:], uint32(addrs))
+		o.PutUint32(b[8:], uint32(m.Flags))
+		o.PutUint16(b[12:], uint16(m.Index))
+		l.putIfData(b[16:], &m.Data)
+		return l.finish(b, typ)
+
+	case *InterfaceAddrMessage:
+		if kind != headerInterfaceAddr {
+			break
+		}
+		if b, addrs, err = l.appendAddrs(b, &m.Addrs); err != nil {
+			return nil, err
+		}
+		o.PutUint32(b[4:], uint32(addrs))
+		o.PutUint32(b[8:], uint32(m.Flags))
+		o.PutUint16(b[12:], uint16(m.Index))
+		o.PutUint32(b[16:], uint32(m.Metric))
+		return l.finish(b, typ)
+
+	case *InterfaceMulticastAddrMessage:
+		if kind != headerMulticastAddr {
+			break
+		}
+		if b, addrs, err = l.appendAddrs(b, &m.Addrs); err != nil {
+			return nil, err
+		}
+		o.PutUint32(b[4:], uint32(addrs))
+		o.PutUint32(b[8:], uint32(m.Flags))
+		o.PutUint16(b[12:], uint16(m.Index))
+		return l.finish(b, typ)
+
+	case *InterfaceAnnounceMessage:
+		if kind != headerAnnounce {
+			break
+		}
+		if len(m.Name) > ifNameSize {
+			return nil, fmt.Errorf("interface name too long: %q", m.Name)
+		}
+		o.PutUint16(b[4:], uint16(m.Index))
+		copy(b[6:], m.Name)
+		o.PutUint16(b[22:], uint16(m.What))
+		return l.finish(append(b, m.Data...), typ)
+	}
+	return nil, fmt.Errorf("%s cannot be encoded as %T", typ, msg)
+}
+
+// finish fills in the length, version and type of an encoded message
+func (l layout) finish(b []byte, typ Type) ([]byte, error) {
+	if len(b) > 0xffff {
+		return nil, fmt.Errorf("routing message too long: %d bytes", len(b))
+	}
+	l.order.PutUint16(b[0:], uint16(len(b)))
+	b[2] = Version
+	b[3] = byte(typ)
+	return b, nil
+}
+
+func (l layout) putLong(b []byte, v uint64) {
+	if l.long == 8 {
+		l.order.PutUint64(b, v)
+	} else {
+		l.order.PutUint32(b, uint32(v))
+	}
+}
+
+func (l layout) getLong(b []byte) uint64 {
+	if l.long == 8 {
+		return l.order.Uint64(b)
+	}
+	return uint64(l.order.Uint32(b))
+}
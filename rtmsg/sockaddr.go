@@ -0,0 +1,243 @@
+package rtmsg
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Sizes of the sockaddrs
+const (
+	sizeofSockaddrIn  = 16
+	sizeofSockaddrIn6 = 28
+	sizeofSockaddrDL  = 54
+)
+
+// saSize returns the space a sockaddr of length n occupies in a message
+// (SA_SIZE): n rounded up to sizeof(long), and sizeof(long) for an empty
+// sockaddr
+func (l layout) saSize(n int) int {
+	if n == 0 {
+		return l.long
+	}
+	return (n + l.long - 1) &^ (l.long - 1)
+}
+
+// appendAddrs appends the present addresses, each padded to its SA_SIZE,
+// and returns the rtm_addrs bitmask
+func (l layout) appendAddrs(b []byte, addrs *[NumAddrs]Sockaddr) ([]byte, int, error) {
+	var bits int
+	for i, sa := range addrs {
+		if sa == nil {
+			continue
+		}
+		enc, err := l.marshalSockaddr(sa)
+		if err != nil {
+			return nil, 0, fmt.Errorf("address %d: %w", i, err)
+		}
+		bits |= 1 << i
+		b = append(b, enc...)
+		b = append(b, make([]byte, l.saSize(len(enc))-len(enc))...)
+	}
+	return b, bits, nil
+}
+
+// parseAddrs decodes the addresses selected by the rtm_addrs bitmask
+func (l layout) parseAddrs(bits int, b []byte) ([NumAddrs]Sockaddr, error) {
+	var addrs [NumAddrs]Sockaddr
+	var raw [NumAddrs][]byte
+	for i := range raw {
+		if bits&(1<<i) == 0 {
+			continue
+		}
+		if len(b) == 0 {
+			return addrs, fmt.Errorf("%w: address %d missing", ErrMalformed, i)
+		}
+		n := int(b[0])
+		if n > len(b) {
+			return addrs, fmt.Errorf("%w: sockaddr length %d of %d bytes", ErrMalformed, n, len(b))
+		}
+		raw[i] = b[:n]
+		b = b[min(l.saSize(n), len(b)):]
+	}
+
+	// Masks are often truncated and carry no family; they take the
+	// family of the destination or interface address
+	hint := AFUnspec
+	for _, i := range []int{AddrDst, AddrIfa} {
+		if len(raw[i]) > 1 && (raw[i][1] == AFInet || raw[i][1] == AFInet6) {
+			hint = int(raw[i][1])
+			break
+		}
+	}
+
+	for i := range raw {
+		if bits&(1<<i) == 0 {
+			continue
+		}
+		if i == AddrNetmask || i == AddrGenmask {
+			addrs[i] = parseMask(raw[i], hint)
+		} else {
+			addrs[i] = l.parseSockaddr(raw[i])
+		}
+	}
+	return addrs, nil
+}
+
+func (l layout) marshalSockaddr(sa Sockaddr) ([]byte, error) {
+	switch sa := sa.(type) {
+	case *Inet4Addr:
+		if sa == nil {
+			break
+		}
+		b := make([]byte, sizeofSockaddrIn)
+		b[0] = sizeofSockaddrIn
+		b[1] = AFInet
+		copy(b[4:8], sa.IP[:])
+		return b, nil
+
+	case *Inet6Addr:
+		if sa == nil {
+			break
+		}
+		b := make([]byte, sizeofSockaddrIn6)
+		b[0] = sizeofSockaddrIn6
+		b[1] = AFInet6
+		copy(b[8:24], sa.IP[:])
+		if sa.ZoneID != 0 && isScoped(sa.IP) {
+			// KAME embedded scope, as route(8) and ndp(8) send it
+			binary.BigEndian.PutUint16(b[10:], uint16(sa.ZoneID))
+		}
+		l.order.PutUint32(b[24:], sa.ZoneID)
+		return b, nil
+
+	case *LinkAddr:
+		if sa == nil {
+			break
+		}
+		n := 8 + len(sa.Name) + len(sa.Addr)
+		if n > 255 {
+			return nil, fmt.Errorf("link address too long: %d bytes", n)
+		}
+		b := make([]byte, max(n, sizeofSockaddrDL))
+		b[0] = byte(len(b))
+		b[1] = AFLink
+		l.order.PutUint16(b[2:], uint16(sa.Index))
+		b[4] = byte(sa.Type)
+		b[5] = byte(len(sa.Name))
+		b[6] = byte(len(sa.Addr))
+		copy(b[8:], sa.Name)
+		copy(b[8+len(sa.Name):], sa.Addr)
+		return b, nil
+
+	case *RawAddr:
+		if sa == nil {
+			break
+		}
+		n := 2 + len(sa.Data)
+		if n > 255 {
+			return nil, fmt.Errorf("sockaddr too long: %d bytes", n)
+		}
+		b := make([]byte, n)
+		b[0] = byte(n)
+		b[1] = byte(sa.AF)
+		copy(b[2:], sa.Data)
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported sockaddr %T", sa)
+}
+
+// parseSockaddr decodes a sockaddr of any family. Short inet and inet6
+// sockaddrs decode as the unspecified address.
+func (l layout) parseSockaddr(b []byte) Sockaddr {
+	family := AFUnspec
+	if len(b) > 1 {
+		family = int(b[1])
+	}
+
+	switch family {
+	case AFInet:
+		sa := &Inet4Addr{}
+		if len(b) >= 8 {
+			copy(sa.IP[:], b[4:8])
+		}
+		return sa
+
+	case AFInet6:
+		sa := &Inet6Addr{}
+		if len(b) >= 24 {
+			copy(sa.IP[:], b[8:24])
+		}
+		if len(b) >= sizeofSockaddrIn6 {
+			sa.ZoneID = l.order.Uint32(b[24:])
+		}
+		if isScoped(sa.IP) && (sa.IP[2] != 0 || sa.IP[3] != 0) {
+			// KAME embedded scope (net.inet6.ip6.deembed_scopeid=0)
+			if sa.ZoneID == 0 {
+				sa.ZoneID = uint32(binary.BigEndian.Uint16(sa.IP[2:]))
+			}
+			sa.IP[2], sa.IP[3] = 0, 0
+		}
+		return sa
+
+	case AFLink:
+		sa := &LinkAddr{}
+		if len(b) < 8 {
+			return sa
+		}
+		sa.Index = int(l.order.Uint16(b[2:]))
+		sa.Type = int(b[4])
+		nlen, alen := int(b[5]), int(b[6])
+		if 8+nlen+alen <= len(b) {
+			sa.Name = string(b[8 : 8+nlen])
+			if alen > 0 {
+				sa.Addr = append([]byte(nil), b[8+nlen:8+nlen+alen]...)
+			}
+		}
+		return sa
+	}
+	return parseRaw(b)
+}
+
+// parseMask decodes a possibly truncated netmask in the given family, or
+// in its own if the family is AFUnspec
+func parseMask(b []byte, family int) Sockaddr {
+	if family == AFUnspec && len(b) > 1 {
+		family = int(b[1])
+	}
+
+	switch family {
+	case AFInet:
+		sa := &Inet4Addr{}
+		if len(b) > 4 {
+			copy(sa.IP[:], b[4:])
+		}
+		return sa
+	case AFInet6:
+		sa := &Inet6Addr{}
+		if len(b) > 8 {
+			copy(sa.IP[:], b[8:])
+		}
+		return sa
+	}
+	return parseRaw(b)
+}
+
+func parseRaw(b []byte) Sockaddr {
+	sa := &RawAddr{}
+	if len(b) > 1 {
+		sa.AF = int(b[1])
+	}
+	if len(b) > 2 {
+		sa.Data = append([]byte(nil), b[2:]...)
+	}
+	return sa
+}
+
+// isScoped reports whether an IPv6 address has link-local or
+// interface-local scope, and so is qualified by a zone
+func isScoped(ip [16]byte) bool {
+	if ip[0] == 0xfe && ip[1]&0xc0 == 0x80 {
+		return true // fe80::/10
+	}
+	return ip[0] == 0xff && (ip[1]&0x0f == 0x01 || ip[1]&0x0f == 0x02)
+}
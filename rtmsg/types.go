@@ -0,0 +1,250 @@
+package rtmsg
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Version is the message version of the routing socket (RTM_VERSION).
+// Messages of other versions are skipped.
+const Version = 5
+
+// Type is a routing message type (RTM_*).
+type Type int
+
+const (
+	TypeAdd        Type = 0x1  // Add a route (RTM_ADD)
+	TypeDelete     Type = 0x2  // Delete a route (RTM_DELETE)
+	TypeChange     Type = 0x3  // Change the gateway, interface or metrics of a route (RTM_CHANGE)
+	TypeGet        Type = 0x4  // Look up a route (RTM_GET)
+	TypeLosing     Type = 0x5  // Kernel suspects a route is failing (RTM_LOSING)
+	TypeRedirect   Type = 0x6  // ICMP redirect to another gateway (RTM_REDIRECT)
+	TypeMiss       Type = 0x7  // Lookup found no route (RTM_MISS)
+	TypeLock       Type = 0x8  // Lock route metrics (RTM_LOCK)
+	TypeResolve    Type = 0xb  // Resolve a destination to a link-layer address (RTM_RESOLVE)
+	TypeNewAddr    Type = 0xc  // Address added to an interface (RTM_NEWADDR)
+	TypeDelAddr    Type = 0xd  // Address removed from an interface (RTM_DELADDR)
+	TypeIfInfo     Type = 0xe  // Interface flags or link state changed (RTM_IFINFO)
+	TypeNewMAddr   Type = 0xf  // Multicast group joined (RTM_NEWMADDR)
+	TypeDelMAddr   Type = 0x10 // Multicast group left (RTM_DELMADDR)
+	TypeIfAnnounce Type = 0x11 // Interface arrived or departed (RTM_IFANNOUNCE)
+	TypeIEEE80211  Type = 0x12 // IEEE 802.11 event (RTM_IEEE80211)
+)
+
+var typeNames = map[Type]string{
+	TypeAdd:        "RTM_ADD",
+	TypeDelete:     "RTM_DELETE",
+	TypeChange:     "RTM_CHANGE",
+	TypeGet:        "RTM_GET",
+	TypeLosing:     "RTM_LOSING",
+	TypeRedirect:   "RTM_REDIRECT",
+	TypeMiss:       "RTM_MISS",
+	TypeLock:       "RTM_LOCK",
+	TypeResolve:    "RTM_RESOLVE",
+	TypeNewAddr:    "RTM_NEWADDR",
+	TypeDelAddr:    "RTM_DELADDR",
+	TypeIfInfo:     "RTM_IFINFO",
+	TypeNewMAddr:   "RTM_NEWMADDR",
+	TypeDelMAddr:   "RTM_DELMADDR",
+	TypeIfAnnounce: "RTM_IFANNOUNCE",
+	TypeIEEE80211:  "RTM_IEEE80211",
+}
+
+// String returns the C name of the type, e.g. "RTM_ADD".
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("RTM_%#x", int(t))
+}
+
+// Indexes of the addresses in a message (RTAX_*). The rtm_addrs bitmask
+// of a message has bit 1<<i set for each address present.
+const (
+	AddrDst     = 0 // Destination (RTAX_DST)
+	AddrGateway = 1 // Gateway (RTAX_GATEWAY)
+	AddrNetmask = 2 // Netmask of the destination or interface address (RTAX_NETMASK)
+	AddrGenmask = 3 // Cloning mask (RTAX_GENMASK)
+	AddrIfp     = 4 // Interface, a LinkAddr (RTAX_IFP)
+	AddrIfa     = 5 // Interface address (RTAX_IFA)
+	AddrAuthor  = 6 // Sender of a redirect (RTAX_AUTHOR)
+	AddrBrd     = 7 // Broadcast or point-to-point peer address (RTAX_BRD)
+	NumAddrs    = 8 // RTAX_MAX
+)
+
+// Address families of sockaddrs
+const (
+	AFUnspec = 0
+	AFInet   = 2
+	AFLink   = 18
+	AFInet6  = 28
+)
+
+// What an interface announcement reports (IFAN_*)
+const (
+	AnnounceArrival   = 0
+	AnnounceDeparture = 1
+)
+
+// ErrMalformed is returned for buffers that are not valid routing
+// messages.
+var ErrMalformed = errors.New("malformed routing message")
+
+// Message is a routing socket message: a *RouteMessage,
+// *InterfaceMessage, *InterfaceAddrMessage, *InterfaceMulticastAddrMessage
+// or *InterfaceAnnounceMessage.
+type Message interface {
+	// MessageType returns the RTM_* type of the message
+	MessageType() Type
+	// Marshal encodes the message in the kernel's wire format
+	Marshal() ([]byte, error)
+}
+
+// RouteMessage is a message with a struct rt_msghdr: route changes,
+// lookups, misses and redirects.
+type RouteMessage struct {
+	Type    Type
+	Index   int // Interface index
+	Flags   int // RTF_* flags
+	Pid     int // Sending process, 0 for the kernel
+	Seq     int // Sequence number chosen by the sender
+	Errno   int // Error of a failed request
+	Fmask   int // Flags to change with RTM_CHANGE
+	Inits   uint64
+	Metrics Metrics
+	Addrs   [NumAddrs]Sockaddr // Indexed by Addr*, nil if absent
+}
+
+// Metrics mirrors struct rt_metrics. The RTV_* bits of Inits select the
+// metrics a request sets.
+type Metrics struct {
+	Locks    uint64
+	MTU      uint64
+	Hopcount uint64
+	Expire   uint64 // Wall-clock time in seconds, 0 if the route does not expire
+	Recvpipe uint64
+	Sendpipe uint64
+	Ssthresh uint64
+	RTT      uint64
+	RTTVar   uint64
+	Pksent   uint64
+	Weight   uint64 // Also rmx_state of neighbor cache entries
+	Nhidx    uint64 // Nexthop index
+}
+
+// InterfaceMessage is a struct if_msghdr, reporting interface flags and
+// link state.
+type InterfaceMessage struct {
+	Type  Type
+	Index int // Interface index
+	Flags int // IFF_* flags
+	Data  InterfaceData
+	Addrs [NumAddrs]Sockaddr // Usually the interface's LinkAddr at AddrIfp
+}
+
+// InterfaceData mirrors struct if_data.
+type InterfaceData struct {
+	Type       int // IFT_* interface type
+	Physical   int
+	AddrLen    int // Length of the link-layer address
+	HdrLen     int // Length of the link-layer header
+	LinkState  int // LINK_STATE_*
+	VHID       int // CARP vhid
+	MTU        int
+	Metric     int
+	Baudrate   uint64
+	IPackets   uint64
+	IErrors    uint64
+	OPackets   uint64
+	OErrors    uint64
+	Collisions uint64
+	IBytes     uint64
+	OBytes     uint64
+	IMcasts    uint64
+	OMcasts    uint64
+	IQDrops    uint64
+	OQDrops    uint64
+	NoProto    uint64
+	HWAssist   uint64
+	Epoch      int64     // Uptime in seconds when the interface was attached or its counters reset
+	LastChange time.Time // Last administrative change, zero if never
+}
+
+// InterfaceAddrMessage is a struct ifa_msghdr, reporting an address added
+// to or removed from an interface.
+type InterfaceAddrMessage struct {
+	Type   Type
+	Index  int // Interface index
+	Flags  int // IFF_* flags of the interface
+	Metric int
+	Addrs  [NumAddrs]Sockaddr // The address at AddrIfa, its netmask and broadcast address
+}
+
+// InterfaceMulticastAddrMessage is a struct ifma_msghdr, reporting a
+// multicast group joined or left on an interface.
+type InterfaceMulticastAddrMessage struct {
+	Type  Type
+	Index int // Interface index
+	Flags int
+	Addrs [NumAddrs]Sockaddr // The group at AddrIfa, its link-layer address at AddrGateway
+}
+
+// InterfaceAnnounceMessage is a struct if_announcemsghdr, reporting an
+// interface that arrived or departed, or an IEEE 802.11 event.
+type InterfaceAnnounceMessage struct {
+	Type  Type
+	Index int    // Interface index
+	Name  string // Interface name
+	What  int    // AnnounceArrival or AnnounceDeparture, or the 802.11 event
+	Data  []byte // Event data following the header of RTM_IEEE80211 messages
+}
+
+func (m *RouteMessage) MessageType() Type                  { return m.Type }
+func (m *InterfaceMessage) MessageType() Type              { return m.Type }
+func (m *InterfaceAddrMessage) MessageType() Type          { return m.Type }
+func (m *InterfaceMulticastAddrMessage) MessageType() Type { return m.Type }
+func (m *InterfaceAnnounceMessage) MessageType() Type      { return m.Type }
+
+// Sockaddr is a socket address carried in a routing message: an
+// *Inet4Addr, *Inet6Addr, *LinkAddr or *RawAddr.
+type Sockaddr interface {
+	Family() int
+}
+
+// Inet4Addr is a struct sockaddr_in. IPv4 netmasks are Inet4Addrs as well.
+type Inet4Addr struct {
+	IP [4]byte
+}
+
+// Inet6Addr is a struct sockaddr_in6. IPv6 netmasks are Inet6Addrs as well.
+//
+// The zone of link-local and interface-local addresses is the interface
+// index in ZoneID. The kernel may embed it in the address (KAME scope);
+// decoding moves it to ZoneID and encoding embeds it again, as route(8)
+// does.
+type Inet6Addr struct {
+	IP     [16]byte
+	ZoneID uint32
+}
+
+// LinkAddr is a struct sockaddr_dl: an interface and its link-layer
+// address.
+type LinkAddr struct {
+	Index int    // Interface index
+	Type  int    // IFT_* interface type
+	Name  string // Interface name, may be empty
+	Addr  []byte // Link-layer address, nil if none
+}
+
+// RawAddr is a sockaddr of another family, or a netmask whose family is
+// unknown. Data holds the bytes following sa_len and sa_family.
+type RawAddr struct {
+	AF   int
+	Data []byte
+}
+
+func (*Inet4Addr) Family() int  { return AFInet }
+func (*Inet6Addr) Family() int  { return AFInet6 }
+func (*LinkAddr) Family() int   { return AFLink }
+func (sa *RawAddr) Family() int { return sa.AF }
@@ -0,0 +1,113 @@
+//go:build freebsd
+// +build freebsd
+
+package sysctl
+
+import (
+	"fmt"
+
+	isyscall "github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Name returns the sysctl name, e.g. "net.inet.ip.forwarding".
+func (b Bool) Name() string { return string(b) }
+
+// Get reports whether the sysctl is enabled.
+//
+// Returns ErrNotFound if the sysctl does not exist, usually because the
+// kernel module that provides it is not loaded.
+func (b Bool) Get() (bool, error) {
+	v, err := GetInt(string(b))
+	if err != nil {
+		return false, err
+	}
+	return v != 0, nil
+}
+
+// Set enables or disables the sysctl.
+//
+// Requires root privileges.
+//
+// Example:
+//
+//	// sysctl net.inet.ip.forwarding=1
+//	if err := sysctl.IPForwarding.Set(true); err != nil {
+//		log.Fatal(err)
+//	}
+func (b Bool) Set(enable bool) error {
+	var v int64
+	if enable {
+		v = 1
+	}
+	return SetInt(string(b), v)
+}
+
+// Name returns the sysctl name, e.g. "net.inet.ip.ttl".
+func (i Int) Name() string { return string(i) }
+
+// Get returns the value of the sysctl.
+func (i Int) Get() (int64, error) { return GetInt(string(i)) }
+
+// Set changes the value of the sysctl.
+//
+// Requires root privileges.
+func (i Int) Set(v int64) error { return SetInt(string(i), v) }
+
+// Name returns the sysctl name, e.g. "net.inet.tcp.cc.algorithm".
+func (s String) Name() string { return string(s) }
+
+// Get returns the value of the sysctl.
+func (s String) Get() (string, error) { return GetString(string(s)) }
+
+// Set changes the value of the sysctl.
+//
+// Requires root privileges.
+func (s String) Set(v string) error { return SetString(string(s), v) }
+
+// GetInt reads any integer sysctl by name.
+//
+// int, long and 64-bit sysctls are supported, signed or unsigned. The
+// type is taken from the sysctl's declaration; other types, strings
+// included, return ErrNotSupported.
+//
+// Example:
+//
+//	v, err := sysctl.GetInt("net.inet.tcp.msl")
+func GetInt(name string) (int64, error) {
+	v, err := isyscall.SysctlInt64(name)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// SetInt writes any integer sysctl by name.
+//
+// The value is written with the width of the sysctl's type; values that
+// do not fit a 32-bit sysctl return a validation error, other types
+// ErrNotSupported. Requires root privileges.
+func SetInt(name string, v int64) error {
+	if err := isyscall.SetSysctlInt64(name, v); err != nil {
+		return fmt.Errorf("set %s=%d: %w", name, v, err)
+	}
+	return nil
+}
+
+// GetString reads a string sysctl by name.
+func GetString(name string) (string, error) {
+	v, err := isyscall.SysctlString(name)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// SetString writes a string sysctl by name.
+//
+// Requires root privileges.
+func SetString(name, v string) error {
+	if err := isyscall.SetSysctlString(name, v); err != nil {
+		return fmt.Errorf("set %s=%q: %w", name, v, err)
+	}
+	return nil
+}
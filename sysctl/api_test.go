@@ -0,0 +1,104 @@
+//go:build freebsd
+// +build freebsd
+
+package sysctl
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func skipIfNotRoot(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Test requires root privileges")
+	}
+}
+
+func skipIfNotE2E(t *testing.T) {
+	if os.Getenv("IFCLIB_E2E") != "1" {
+		t.Skip("Set IFCLIB_E2E=1 to run E2E tests")
+	}
+}
+
+// TestGet tests reading typed sysctls that always exist
+func TestGet(t *testing.T) {
+	if _, err := IPForwarding.Get(); err != nil {
+		t.Errorf("%s.Get() failed: %v", IPForwarding.Name(), err)
+	}
+	if _, err := IP6Forwarding.Get(); err != nil {
+		t.Errorf("%s.Get() failed: %v", IP6Forwarding.Name(), err)
+	}
+
+	fibs, err := FIBs.Get()
+	if err != nil {
+		t.Fatalf("%s.Get() failed: %v", FIBs.Name(), err)
+	}
+	if fibs < 1 {
+		t.Errorf("Expected at least one FIB, got %d", fibs)
+	}
+
+	ttl, err := IPTTL.Get()
+	if err != nil {
+		t.Fatalf("%s.Get() failed: %v", IPTTL.Name(), err)
+	}
+	if ttl < 1 || ttl > 255 {
+		t.Errorf("Unexpected TTL %d", ttl)
+	}
+}
+
+// TestGetGeneric tests the generic accessors
+func TestGetGeneric(t *testing.T) {
+	ostype, err := GetString("kern.ostype")
+	if err != nil {
+		t.Fatalf("GetString() failed: %v", err)
+	}
+	if ostype != "FreeBSD" {
+		t.Errorf("Expected kern.ostype FreeBSD, got %q", ostype)
+	}
+
+	// kern.maxvnodes is a long
+	if _, err := GetInt("kern.maxvnodes"); err != nil {
+		t.Errorf("GetInt() of a long failed: %v", err)
+	}
+
+	if _, err := GetInt("net.inet.ip.does_not_exist"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	// "FreeBSD\0" is as long as an int64
+	if _, err := GetInt("kern.ostype"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("GetInt() of a string should return ErrNotSupported, got %v", err)
+	}
+	if err := SetInt("kern.ostype", 1); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("SetInt() of a string should return ErrNotSupported, got %v", err)
+	}
+}
+
+// TestSet tests writing a sysctl and restoring it
+func TestSet(t *testing.T) {
+	skipIfNotRoot(t)
+	skipIfNotE2E(t)
+
+	old, err := IPRedirect.Get()
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	defer IPRedirect.Set(old)
+
+	if err := IPRedirect.Set(!old); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	v, err := IPRedirect.Get()
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if v == old {
+		t.Errorf("Expected %v after Set, got %v", !old, v)
+	}
+
+	// Read-only tunables cannot be changed
+	if err := FIBs.Set(1); err == nil {
+		t.Error("Set() of a read-only tunable should fail")
+	}
+}
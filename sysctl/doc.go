@@ -0,0 +1,42 @@
+/*
+Package sysctl provides typed access to FreeBSD network sysctls.
+
+Many settings that go with interface configuration are sysctls rather than
+ioctls: forwarding, packet filtering on bridges, tap behaviour and firewall
+hooks. This package exposes a curated set of them as typed constants, plus
+generic accessors for any other sysctl.
+
+# Basic Usage
+
+Typed sysctls have Get and Set methods:
+
+	// sysctl net.inet.ip.forwarding=1 net.inet6.ip6.forwarding=1
+	for _, knob := range []sysctl.Bool{sysctl.IPForwarding, sysctl.IP6Forwarding} {
+		if err := knob.Set(true); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Filter bridged traffic on the bridge, not on every member
+	sysctl.BridgePfilMember.Set(false)
+	sysctl.BridgePfilBridge.Set(true)
+
+	fibs, err := sysctl.FIBs.Get()
+
+Any other sysctl is available by name:
+
+	msl, err := sysctl.GetInt("net.inet.tcp.msl")
+	err = sysctl.SetString("net.inet.tcp.cc.algorithm", "htcp")
+
+# Kernel Modules
+
+Sysctls provided by a module, such as the bridge, epair, ipfw and carp
+ones, only exist while the module is loaded. Reading or writing them
+otherwise returns ErrNotFound.
+
+# Permissions
+
+Reading works without special privileges. Setting requires root
+privileges; boot-time tunables such as FIBs and IfqMaxLen are read-only.
+*/
+package sysctl
@@ -0,0 +1,73 @@
+//go:build freebsd
+// +build freebsd
+
+package sysctl
+
+import (
+	"github.com/zombocoder/go-freebsd-ifc/internal/constants"
+	"github.com/zombocoder/go-freebsd-ifc/internal/syscall"
+)
+
+// Bool is an on/off sysctl, stored by the kernel as 0 or 1.
+type Bool string
+
+// Int is an integer sysctl.
+type Int string
+
+// String is a string sysctl.
+type String string
+
+// Forwarding and routing
+const (
+	IPForwarding   Bool = constants.SysctlIPForwarding   // Forward IPv4 packets between interfaces
+	IP6Forwarding  Bool = constants.SysctlIP6Forwarding  // Forward IPv6 packets between interfaces
+	IPRedirect     Bool = "net.inet.ip.redirect"         // Send ICMP redirects
+	IP6Redirect    Bool = "net.inet6.ip6.redirect"       // Send ICMPv6 redirects
+	IP6AcceptRtadv Bool = "net.inet6.ip6.accept_rtadv"   // Default for accepting Router Advertisements
+	PfilForward    Bool = "net.pfil.forward"             // Let packet filters change the next hop
+	RouteMultipath Bool = constants.SysctlRouteMultipath // Allow several nexthops per destination (ROUTE_MPATH)
+
+	IPTTL       Int = "net.inet.ip.ttl"    // Default IPv4 TTL
+	IP6HopLimit Int = "net.inet6.ip6.hlim" // Default IPv6 hop limit
+	FIBs        Int = "net.fibs"           // Number of routing tables (boot-time tunable, read-only)
+)
+
+// Bridge, requires if_bridge(4)
+const (
+	BridgePfilMember Bool = "net.link.bridge.pfil_member" // Filter on the member interfaces
+	BridgePfilBridge Bool = "net.link.bridge.pfil_bridge" // Filter on the bridge interface
+	BridgePfilOnlyIP Bool = "net.link.bridge.pfil_onlyip" // Only pass IP packets when filtering
+	BridgeInheritMAC Bool = "net.link.bridge.inherit_mac" // Bridge takes the MAC of its first member
+	BridgeLogSTP     Bool = "net.link.bridge.log_stp"     // Log spanning tree state changes
+)
+
+// TAP and epair, requires if_tuntap(4) and if_epair(4)
+const (
+	TapUpOnOpen Bool = "net.link.tap.up_on_open" // Bring a tap interface up when its device is opened
+	TapUserOpen Bool = "net.link.tap.user_open"  // Let unprivileged users open tap devices
+
+	EpairNetisrMaxQLen Int = "net.link.epair.netisr_maxqlen" // Queue length between the two ends
+)
+
+// Firewall and CARP, requires ipfw(4) and carp(4)
+const (
+	IPFWEnable  Bool = "net.inet.ip.fw.enable"   // Pass IPv4 packets through ipfw
+	IP6FWEnable Bool = "net.inet6.ip6.fw.enable" // Pass IPv6 packets through ipfw
+	CarpAllow   Bool = "net.inet.carp.allow"     // Accept incoming CARP packets
+	CarpPreempt Bool = "net.inet.carp.preempt"   // Preempt MASTER and fail over all vhids together
+)
+
+// Link layer
+const (
+	IfqMaxLen Int = "net.link.ifqmaxlen"          // Default send queue length (boot-time tunable, read-only)
+	ARPMaxAge Int = "net.link.ether.inet.max_age" // Lifetime of ARP entries in seconds
+
+	TCPCongestionControl String = "net.inet.tcp.cc.algorithm" // Default TCP congestion control algorithm
+)
+
+// Re-export common errors from internal package
+var (
+	ErrNotFound     = syscall.ErrNotFound
+	ErrPermission   = syscall.ErrPermission
+	ErrNotSupported = syscall.ErrNotSupported
+)